@@ -0,0 +1,13 @@
+data "googleworkspace_group_members" "sales" {
+  group_id = "sales@example.com"
+}
+
+# Every user of the group, including those that are members through nested groups
+data "googleworkspace_group_members" "engineering" {
+  group_id  = "engineering@example.com"
+  recursive = true
+}
+
+output "engineering_emails" {
+  value = data.googleworkspace_group_members.engineering.members[*].email
+}
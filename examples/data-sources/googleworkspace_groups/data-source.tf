@@ -0,0 +1,18 @@
+data "googleworkspace_groups" "sales" {
+  query = "email:sales-*"
+}
+
+# Applies the same settings to every group that follows the naming convention
+resource "googleworkspace_group_settings" "sales" {
+  for_each = { for group in data.googleworkspace_groups.sales.groups : group.email => group }
+
+  email                  = each.key
+  who_can_join           = "INVITED_CAN_JOIN"
+  who_can_view_group     = "ALL_MEMBERS_CAN_VIEW"
+  who_can_post_message   = "ALL_IN_DOMAIN_CAN_POST"
+  allow_external_members = false
+}
+
+data "googleworkspace_groups" "dwight" {
+  user_key = "dwight.schrute@example.com"
+}
@@ -0,0 +1,18 @@
+data "googleworkspace_org_units" "schools" {
+  org_unit_path = "/Schools"
+  type          = "children"
+}
+
+# Applies a baseline policy to every school's org unit
+resource "googleworkspace_chrome_policy" "baseline" {
+  for_each = { for ou in data.googleworkspace_org_units.schools.org_units : ou.org_unit_path => ou }
+
+  org_unit_id = each.value.org_unit_id
+
+  policies {
+    schema_name = "chrome.users.MaxConnectionsPerProxy"
+    schema_values = {
+      maxConnectionsPerProxy = jsonencode(34)
+    }
+  }
+}
@@ -0,0 +1,23 @@
+data "googleworkspace_roles" "all" {}
+
+data "googleworkspace_role_assignments" "all" {}
+
+locals {
+  role_names = { for role in data.googleworkspace_roles.all.roles : role.id => role.name }
+}
+
+# Every admin assignment, including those made in the Admin console
+output "admin_assignments" {
+  value = [
+    for ra in data.googleworkspace_role_assignments.all.role_assignments : {
+      role        = local.role_names[ra.role_id]
+      assigned_to = ra.assigned_to
+      scope_type  = ra.scope_type
+      org_unit_id = ra.org_unit_id
+    }
+  ]
+}
+
+data "googleworkspace_role_assignments" "dwight" {
+  user_key = "dwight.schrute@example.com"
+}
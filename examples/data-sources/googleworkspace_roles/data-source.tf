@@ -0,0 +1,8 @@
+data "googleworkspace_roles" "all" {}
+
+output "super_admin_roles" {
+  value = [
+    for role in data.googleworkspace_roles.all.roles : role.name
+    if role.is_super_admin_role
+  ]
+}
@@ -0,0 +1,11 @@
+data "googleworkspace_users" "sales" {
+  query = "orgDepartment='Sales' isSuspended=false"
+}
+
+data "googleworkspace_users" "engineering" {
+  org_unit_path = "/Engineering"
+}
+
+output "sales_emails" {
+  value = data.googleworkspace_users.sales.users[*].primary_email
+}
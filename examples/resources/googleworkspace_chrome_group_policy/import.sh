@@ -0,0 +1,2 @@
+# The policies matching the filters that are set on the group are imported, with the additional target keys url-encoded after a '?'
+terraform import googleworkspace_chrome_group_policy.translate "01234567890abcd/chrome.users.apps.*?app_id=chrome%3Aaapbdbdomjkkjkaonfhkkikfgjllcleb"
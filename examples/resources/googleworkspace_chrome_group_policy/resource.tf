@@ -0,0 +1,19 @@
+resource "googleworkspace_group" "pilot" {
+  email = "browser-pilot@example.com"
+}
+
+# Rolls out an app to the members of the group, whichever org unit they're in
+resource "googleworkspace_chrome_group_policy" "translate" {
+  group_id = googleworkspace_group.pilot.id
+
+  additional_target_keys = {
+    "app_id" = "chrome:aapbdbdomjkkjkaonfhkkikfgjllcleb"
+  }
+
+  policies {
+    schema_name = "chrome.users.apps.InstallType"
+    schema_values = {
+      appInstallType = jsonencode("APP_INSTALL_TYPE_ENUM_FORCED")
+    }
+  }
+}
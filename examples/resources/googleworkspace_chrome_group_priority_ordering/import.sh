@@ -0,0 +1,2 @@
+# The additional target keys are url-encoded after a '?'
+terraform import googleworkspace_chrome_group_priority_ordering.translate "chrome.users.apps?app_id=chrome%3Aaapbdbdomjkkjkaonfhkkikfgjllcleb"
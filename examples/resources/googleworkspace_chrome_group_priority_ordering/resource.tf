@@ -0,0 +1,57 @@
+resource "googleworkspace_group" "pilot" {
+  email = "browser-pilot@example.com"
+}
+
+resource "googleworkspace_group" "contractors" {
+  email = "contractors@example.com"
+}
+
+resource "googleworkspace_chrome_group_policy" "pilot" {
+  group_id = googleworkspace_group.pilot.id
+
+  additional_target_keys = {
+    "app_id" = "chrome:aapbdbdomjkkjkaonfhkkikfgjllcleb"
+  }
+
+  policies {
+    schema_name = "chrome.users.apps.InstallType"
+    schema_values = {
+      appInstallType = jsonencode("APP_INSTALL_TYPE_ENUM_FORCED")
+    }
+  }
+}
+
+resource "googleworkspace_chrome_group_policy" "contractors" {
+  group_id = googleworkspace_group.contractors.id
+
+  additional_target_keys = {
+    "app_id" = "chrome:aapbdbdomjkkjkaonfhkkikfgjllcleb"
+  }
+
+  policies {
+    schema_name = "chrome.users.apps.InstallType"
+    schema_values = {
+      appInstallType = jsonencode("APP_INSTALL_TYPE_ENUM_BLOCKED")
+    }
+  }
+}
+
+# Members of both groups get the policies of the pilot group
+resource "googleworkspace_chrome_group_priority_ordering" "translate" {
+  policy_namespace = "chrome.users.apps"
+
+  additional_target_keys = {
+    "app_id" = "chrome:aapbdbdomjkkjkaonfhkkikfgjllcleb"
+  }
+
+  group_ids = [
+    googleworkspace_group.pilot.id,
+    googleworkspace_group.contractors.id,
+  ]
+
+  # the ordering has every group with policies for the app
+  depends_on = [
+    googleworkspace_chrome_group_policy.pilot,
+    googleworkspace_chrome_group_policy.contractors,
+  ]
+}
@@ -0,0 +1,8 @@
+# The policies set directly on the org unit that match the comma-separated schema names or filters are imported
+terraform import googleworkspace_chrome_policy.example 03ph8a2z1enx4lx/chrome.users.MaxConnectionsPerProxy,chrome.users.RestrictSigninToPattern
+
+# Wildcard filters import every policy of a namespace
+terraform import googleworkspace_chrome_policy.example "03ph8a2z1enx4lx/chrome.users.*"
+
+# Policies identified by additional target keys are imported with the keys url-encoded after a '?'
+terraform import googleworkspace_chrome_policy.example "03ph8a2z1enx4lx/chrome.users.apps.*?app_id=chrome%3Aaapbdbdomjkkjkaonfhkkikfgjllcleb"
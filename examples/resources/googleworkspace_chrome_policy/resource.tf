@@ -11,4 +11,20 @@ resource "googleworkspace_chrome_policy" "example" {
       maxConnectionsPerProxy = jsonencode(34)
     }
   }
-}
\ No newline at end of file
+}
+
+# Policies of apps and printers are identified by additional target keys
+resource "googleworkspace_chrome_policy" "translate" {
+  org_unit_id = googleworkspace_org_unit.example.id
+
+  additional_target_keys = {
+    "app_id" = "chrome:aapbdbdomjkkjkaonfhkkikfgjllcleb"
+  }
+
+  policies {
+    schema_name = "chrome.users.apps.InstallType"
+    schema_values = {
+      appInstallType = jsonencode("APP_INSTALL_TYPE_ENUM_FORCED")
+    }
+  }
+}
@@ -0,0 +1,5 @@
+# Every field of the policy that's set on the org unit is imported
+terraform import googleworkspace_chrome_policy_value.connections 03ph8a2z1enx4lx/chrome.users.MaxConnectionsPerProxy
+
+# Policies identified by additional target keys are imported with the keys url-encoded after a '?'
+terraform import googleworkspace_chrome_policy_value.translate "03ph8a2z1enx4lx/chrome.users.apps.InstallType?app_id=chrome%3Aaapbdbdomjkkjkaonfhkkikfgjllcleb"
@@ -0,0 +1,26 @@
+resource "googleworkspace_org_unit" "example" {
+  name                 = "example"
+  parent_org_unit_path = "/"
+}
+
+# Separate configurations can manage different policies of the same org unit
+resource "googleworkspace_chrome_policy_value" "connections" {
+  org_unit_id = googleworkspace_org_unit.example.id
+  schema_name = "chrome.users.MaxConnectionsPerProxy"
+  schema_values = {
+    maxConnectionsPerProxy = jsonencode(34)
+  }
+}
+
+resource "googleworkspace_chrome_policy_value" "translate" {
+  org_unit_id = googleworkspace_org_unit.example.id
+  schema_name = "chrome.users.apps.InstallType"
+
+  additional_target_keys = {
+    "app_id" = "chrome:aapbdbdomjkkjkaonfhkkikfgjllcleb"
+  }
+
+  schema_values = {
+    appInstallType = jsonencode("APP_INSTALL_TYPE_ENUM_FORCED")
+  }
+}
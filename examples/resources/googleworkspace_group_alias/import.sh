@@ -0,0 +1 @@
+terraform import googleworkspace_group_alias.quotes 01abcde23fg4h5i/quotes@example.com
\ No newline at end of file
@@ -0,0 +1,13 @@
+resource "googleworkspace_group" "sales" {
+  email = "sales@example.com"
+
+  # the aliases of the group are managed by googleworkspace_group_alias
+  lifecycle {
+    ignore_changes = [aliases]
+  }
+}
+
+resource "googleworkspace_group_alias" "quotes" {
+  group_id = googleworkspace_group.sales.id
+  alias    = "quotes@example.com"
+}
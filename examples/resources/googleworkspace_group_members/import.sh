@@ -0,0 +1 @@
+terraform import googleworkspace_group_members.sales groups/01abcde23fg4h5i
\ No newline at end of file
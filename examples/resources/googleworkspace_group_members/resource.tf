@@ -0,0 +1,34 @@
+resource "googleworkspace_group" "sales" {
+  email = "sales@example.com"
+}
+
+resource "googleworkspace_group" "sales_managers" {
+  email = "sales-managers@example.com"
+}
+
+resource "googleworkspace_user" "michael" {
+  primary_email = "michael.scott@example.com"
+  password      = "34819d7beeabb9260a5c854bc85b3e44"
+  hash_function = "MD5"
+
+  name {
+    family_name = "Scott"
+    given_name  = "Michael"
+  }
+}
+
+# Members of the group that aren't listed here are removed from it
+resource "googleworkspace_group_members" "sales" {
+  group_id = googleworkspace_group.sales.id
+
+  members {
+    email = googleworkspace_user.michael.primary_email
+    role  = "OWNER"
+  }
+
+  members {
+    email = googleworkspace_group.sales_managers.email
+    type  = "GROUP"
+    role  = "MANAGER"
+  }
+}
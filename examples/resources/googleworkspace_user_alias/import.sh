@@ -0,0 +1 @@
+terraform import googleworkspace_user_alias.support 123456789012345678901/support@example.com
@@ -0,0 +1,255 @@
+package googleworkspace
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	auditLogFormatJSON = "json"
+	auditLogFormatText = "text"
+
+	redactedValue = "REDACTED"
+)
+
+var auditLogFormats = []string{auditLogFormatJSON, auditLogFormatText}
+
+// Fields whose name contains any of these, ignoring case, are redacted from the
+// audit log, such as a user's `password` or a send-as alias' `smtpMsa.password`.
+var redactedFieldNames = []string{"password", "secret", "token", "privatekey"}
+
+// auditEntry is the record of a single request that changes the tenant.
+type auditEntry struct {
+	Time         string      `json:"time"`
+	Method       string      `json:"method"`
+	URL          string      `json:"url"`
+	Target       string      `json:"target"`
+	Status       int         `json:"status,omitempty"`
+	Error        string      `json:"error,omitempty"`
+	ResourceType string      `json:"resource_type,omitempty"`
+	ResourceID   string      `json:"resource_id,omitempty"`
+	Request      interface{} `json:"request,omitempty"`
+}
+
+// auditLogger appends an entry for each request to the audit log file. It is
+// shared by every client created from the provider.
+type auditLogger struct {
+	mutex  sync.Mutex
+	file   *os.File
+	format string
+}
+
+func newAuditLogger(path, format string) (*auditLogger, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening audit log %q: %s", path, err)
+	}
+
+	if format == "" {
+		format = auditLogFormatJSON
+	}
+
+	return &auditLogger{
+		file:   file,
+		format: format,
+	}, nil
+}
+
+func (l *auditLogger) log(entry *auditEntry) error {
+	var line []byte
+	switch l.format {
+	case auditLogFormatText:
+		line = []byte(formatAuditEntryText(entry))
+	default:
+		var err error
+		line, err = json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+	}
+	line = append(line, '\n')
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	_, err := l.file.Write(line)
+	return err
+}
+
+func formatAuditEntryText(entry *auditEntry) string {
+	fields := []string{
+		fmt.Sprintf("time=%s", entry.Time),
+		fmt.Sprintf("method=%s", entry.Method),
+		fmt.Sprintf("url=%q", entry.URL),
+		fmt.Sprintf("target=%q", entry.Target),
+	}
+	if entry.Status != 0 {
+		fields = append(fields, fmt.Sprintf("status=%d", entry.Status))
+	}
+	if entry.Error != "" {
+		fields = append(fields, fmt.Sprintf("error=%q", entry.Error))
+	}
+	if entry.ResourceType != "" {
+		fields = append(fields, fmt.Sprintf("resource_type=%s", entry.ResourceType))
+	}
+	if entry.ResourceID != "" {
+		fields = append(fields, fmt.Sprintf("resource_id=%q", entry.ResourceID))
+	}
+	if entry.Request != nil {
+		request, _ := json.Marshal(entry.Request)
+		fields = append(fields, fmt.Sprintf("request=%q", request))
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// auditTransport records every request that changes the tenant in the audit
+// log, along with the Terraform resource it was made for, if any.
+type auditTransport struct {
+	base   http.RoundTripper
+	logger *auditLogger
+}
+
+func newAuditTransport(base http.RoundTripper, logger *auditLogger) *auditTransport {
+	return &auditTransport{
+		base:   base,
+		logger: logger,
+	}
+}
+
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// reads, including those that are POSTs, don't change the tenant
+	if isReadOnlyRequest(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	entry := &auditEntry{
+		Method: req.Method,
+		URL:    redactURL(req.URL),
+		Target: apiResourcePath(req.URL),
+	}
+
+	body, err := requestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > 0 {
+		var request interface{}
+		if err := json.Unmarshal(body, &request); err == nil {
+			entry.Request = redact(request)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+
+	entry.Time = time.Now().UTC().Format(time.RFC3339)
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Status = resp.StatusCode
+	}
+
+	if resource, ok := resourceInfoFromContext(req.Context()); ok {
+		entry.ResourceType = resource.resourceType
+		entry.ResourceID = resource.d.Id()
+	}
+
+	if logErr := t.logger.log(entry); logErr != nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return nil, fmt.Errorf("error writing to audit log: %s", logErr)
+	}
+
+	return resp, err
+}
+
+// requestBody returns a copy of the request body, leaving the request able to
+// be sent.
+func requestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+
+		return ioutil.ReadAll(body)
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+// redact replaces the value of sensitive fields in a decoded JSON value.
+func redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if isRedactedField(k) {
+				v[k] = redactedValue
+				continue
+			}
+			v[k] = redact(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redact(item)
+		}
+	}
+
+	return v
+}
+
+func redactURL(u *url.URL) string {
+	redacted := *u
+
+	query := redacted.Query()
+	for k := range query {
+		if isRedactedField(k) {
+			query.Set(k, redactedValue)
+		}
+	}
+	redacted.RawQuery = query.Encode()
+
+	return redacted.String()
+}
+
+func isRedactedField(name string) bool {
+	name = strings.ToLower(name)
+	for _, redacted := range redactedFieldNames {
+		if strings.Contains(name, redacted) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// apiResourcePath returns the path of the API resource targeted by the request,
+// such as `groups/my-group@example.com/members`.
+func apiResourcePath(u *url.URL) string {
+	if idx := strings.Index(u.Path, "/v1/"); idx >= 0 {
+		return u.Path[idx+len("/v1/"):]
+	}
+
+	return strings.TrimPrefix(u.Path, "/")
+}
@@ -0,0 +1,261 @@
+package googleworkspace
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/chromepolicy/v1"
+	"google.golang.org/api/gmail/v1"
+)
+
+func testAuditLogConfig(t *testing.T, server *httptest.Server, format string) (*apiClient, string) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	logger, err := newAuditLogger(path, format)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return &apiClient{
+		auditLogger:             logger,
+		client:                  server.Client(),
+		DirectoryCustomEndpoint: server.URL,
+	}, path
+}
+
+func testAuditLogServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": {"code": 404, "message": "Resource Not Found: user"}}`))
+			return
+		}
+		w.Write([]byte(`{"id": "123"}`))
+	}))
+}
+
+func readAuditLog(t *testing.T, path string) []map[string]interface{} {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("error reading audit log: %s", err)
+	}
+
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		if line == "" {
+			continue
+		}
+
+		entry := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("audit log line %q is not JSON: %s", line, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func TestAuditTransport(t *testing.T) {
+	server := testAuditLogServer()
+	defer server.Close()
+
+	config, path := testAuditLogConfig(t, server, auditLogFormatJSON)
+
+	directoryService, diags := config.NewDirectoryService()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	// the resource id is read when each request is made
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+	r := withResourceInfo("googleworkspace_user", &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if _, err := directoryService.Users.Insert(&directory.User{
+				PrimaryEmail: "user@example.com",
+				Password:     "hunter2",
+			}).Context(ctx).Do(); err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId("123")
+
+			if _, err := directoryService.Users.Get("123").Context(ctx).Do(); err != nil {
+				return diag.FromErr(err)
+			}
+
+			if err := directoryService.Users.Delete("123").Context(ctx).Do(); err == nil {
+				return diag.Errorf("expected an error deleting the user")
+			}
+
+			return nil
+		},
+	})
+
+	if diags := r.CreateContext(context.Background(), d, config); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	entries := readAuditLog(t, path)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 audit log entries, got %d: %v", len(entries), entries)
+	}
+
+	insert := entries[0]
+	expected := map[string]interface{}{
+		"method":        "POST",
+		"target":        "users",
+		"status":        200.0,
+		"resource_type": "googleworkspace_user",
+	}
+	for k, v := range expected {
+		if insert[k] != v {
+			t.Errorf("expected insert entry %q to be %v, got %v", k, v, insert[k])
+		}
+	}
+	if _, ok := insert["resource_id"]; ok {
+		t.Errorf("expected no resource id before the user is created, got %v", insert["resource_id"])
+	}
+
+	request := insert["request"].(map[string]interface{})
+	if request["password"] != redactedValue {
+		t.Errorf("expected password to be redacted, got %v", request["password"])
+	}
+	if request["primaryEmail"] != "user@example.com" {
+		t.Errorf("expected primaryEmail to be recorded, got %v", request["primaryEmail"])
+	}
+
+	del := entries[1]
+	expected = map[string]interface{}{
+		"method":      "DELETE",
+		"target":      "users/123",
+		"status":      404.0,
+		"resource_id": "123",
+	}
+	for k, v := range expected {
+		if del[k] != v {
+			t.Errorf("expected delete entry %q to be %v, got %v", k, v, del[k])
+		}
+	}
+}
+
+func TestAuditTransport_readOnlyPost(t *testing.T) {
+	server := testAuditLogServer()
+	defer server.Close()
+
+	config, path := testAuditLogConfig(t, server, auditLogFormatJSON)
+	config.ChromePolicyCustomEndpoint = server.URL
+
+	chromePolicyService, diags := config.NewChromePolicyService()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	directoryService, diags := config.NewDirectoryService()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	// resolving the policies is a POST, but doesn't change the tenant
+	if _, err := chromePolicyService.Customers.Policies.Resolve("customers/my_customer", &chromepolicy.GoogleChromePolicyV1ResolveRequest{
+		PolicySchemaFilter: "chrome.users.MaxConnectionsPerProxy",
+		PolicyTargetKey: &chromepolicy.GoogleChromePolicyV1PolicyTargetKey{
+			TargetResource: "orgunits/123",
+		},
+	}).Do(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := directoryService.Users.Insert(&directory.User{
+		PrimaryEmail: "user@example.com",
+	}).Do(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	entries := readAuditLog(t, path)
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 audit log entry, got %d: %v", len(entries), entries)
+	}
+
+	if entries[0]["target"] != "users" {
+		t.Errorf("expected only the insert to be recorded, got %v", entries[0])
+	}
+}
+
+func TestRedact_nested(t *testing.T) {
+	body, err := json.Marshal(&gmail.SendAs{
+		SendAsEmail: "alias@example.com",
+		SmtpMsa: &gmail.SmtpMsa{
+			Host:     "smtp.example.com",
+			Password: "hunter2",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var request interface{}
+	if err := json.Unmarshal(body, &request); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	smtpMsa := redact(request).(map[string]interface{})["smtpMsa"].(map[string]interface{})
+	if smtpMsa["password"] != redactedValue {
+		t.Errorf("expected smtpMsa.password to be redacted, got %v", smtpMsa["password"])
+	}
+	if smtpMsa["host"] != "smtp.example.com" {
+		t.Errorf("expected smtpMsa.host to be recorded, got %v", smtpMsa["host"])
+	}
+}
+
+func TestAuditTransport_textFormat(t *testing.T) {
+	server := testAuditLogServer()
+	defer server.Close()
+
+	config, path := testAuditLogConfig(t, server, auditLogFormatText)
+
+	directoryService, diags := config.NewDirectoryService()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if _, err := directoryService.Users.Insert(&directory.User{Password: "hunter2"}).Do(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("error reading audit log: %s", err)
+	}
+
+	line := string(content)
+	for _, expected := range []string{"method=POST", `target="users"`, "status=200"} {
+		if !strings.Contains(line, expected) {
+			t.Errorf("expected audit log line %q to contain %q", line, expected)
+		}
+	}
+	if strings.Contains(line, "hunter2") {
+		t.Errorf("expected password to be redacted from %q", line)
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "https://example.com/v1/users?alt=json&access_token=secret", nil)
+
+	redacted := redactURL(req.URL)
+	if strings.Contains(redacted, "secret") {
+		t.Errorf("expected access_token to be redacted from %q", redacted)
+	}
+	if !strings.Contains(redacted, "alt=json") {
+		t.Errorf("expected alt to be recorded in %q", redacted)
+	}
+}
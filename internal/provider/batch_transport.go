@@ -0,0 +1,344 @@
+package googleworkspace
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	directoryApiPath      = "admin/directory/v1/"
+	directoryBatchApiPath = "batch/admin/directory_v1"
+
+	// The Directory API accepts up to 1000 calls in a single batch request.
+	maxBatchSize = 1000
+)
+
+// Group member and alias mutations are the requests that get batched, as large
+// numbers of them are made concurrently by independent resources.
+var batchableDirectoryRequests = map[string]*regexp.Regexp{
+	http.MethodPost:   regexp.MustCompile(`^(groups/[^/]+/members|(groups|users)/[^/]+/aliases)$`),
+	http.MethodPut:    regexp.MustCompile(`^groups/[^/]+/members/[^/]+$`),
+	http.MethodPatch:  regexp.MustCompile(`^groups/[^/]+/members/[^/]+$`),
+	http.MethodDelete: regexp.MustCompile(`^(groups/[^/]+/members|(groups|users)/[^/]+/aliases)/[^/]+$`),
+}
+
+type batchingConfig struct {
+	// SendAfter is how long requests are collected before a batch is sent.
+	SendAfter time.Duration
+}
+
+func defaultBatchingConfig() *batchingConfig {
+	return &batchingConfig{
+		SendAfter: 500 * time.Millisecond,
+	}
+}
+
+// batchTransport coalesces concurrent Directory API requests into multipart
+// batch requests, and fans the responses of the batch back out to the callers.
+type batchTransport struct {
+	base      http.RoundTripper
+	sendAfter time.Duration
+
+	mutex sync.Mutex
+	// pending batches, keyed by the URL of the batch endpoint
+	pending map[string]*pendingBatch
+}
+
+type pendingBatch struct {
+	url      string
+	requests []*batchedRequest
+	timer    *time.Timer
+}
+
+type batchedRequest struct {
+	req    *http.Request
+	body   []byte
+	result chan batchResult
+}
+
+type batchResult struct {
+	resp *http.Response
+	err  error
+}
+
+func newBatchTransport(base http.RoundTripper, config *batchingConfig) *batchTransport {
+	return &batchTransport{
+		base:      base,
+		sendAfter: config.SendAfter,
+		pending:   map[string]*pendingBatch{},
+	}
+}
+
+func (t *batchTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	batchURL, ok := directoryBatchURL(req)
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	br := &batchedRequest{
+		req:    req,
+		body:   body,
+		result: make(chan batchResult, 1),
+	}
+	t.enqueue(batchURL, br)
+
+	select {
+	case result := <-br.result:
+		return result.resp, result.err
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+}
+
+func (t *batchTransport) enqueue(batchURL string, br *batchedRequest) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	batch, ok := t.pending[batchURL]
+	if !ok {
+		batch = &pendingBatch{url: batchURL}
+		batch.timer = time.AfterFunc(t.sendAfter, func() {
+			t.flush(batch)
+		})
+		t.pending[batchURL] = batch
+	}
+
+	batch.requests = append(batch.requests, br)
+
+	if len(batch.requests) >= maxBatchSize {
+		batch.timer.Stop()
+		delete(t.pending, batchURL)
+		go t.send(batch)
+	}
+}
+
+// flush sends the batch if it is still pending, it may have already been sent
+// because it was full.
+func (t *batchTransport) flush(batch *pendingBatch) {
+	t.mutex.Lock()
+	if t.pending[batch.url] != batch {
+		t.mutex.Unlock()
+		return
+	}
+	delete(t.pending, batch.url)
+	t.mutex.Unlock()
+
+	t.send(batch)
+}
+
+func (t *batchTransport) send(batch *pendingBatch) {
+	// A batch of one is sent as is, there is nothing to coalesce.
+	if len(batch.requests) == 1 {
+		br := batch.requests[0]
+		req := br.req.Clone(br.req.Context())
+		req.Body = ioutil.NopCloser(bytes.NewReader(br.body))
+
+		resp, err := t.base.RoundTrip(req)
+		br.result <- batchResult{resp: resp, err: err}
+		return
+	}
+
+	log.Printf("[DEBUG] Sending batch of %d requests to %s", len(batch.requests), batch.url)
+
+	resps, err := t.sendBatch(batch)
+	for i, br := range batch.requests {
+		if err != nil {
+			br.result <- batchResult{err: err}
+			continue
+		}
+		br.result <- batchResult{resp: resps[i]}
+	}
+}
+
+// sendBatch sends the batch request and returns the response to each request
+// in the batch, in order.
+func (t *batchTransport) sendBatch(batch *pendingBatch) ([]*http.Response, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+
+	for i, br := range batch.requests {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", "application/http")
+		header.Set("Content-ID", fmt.Sprintf("<item%d>", i))
+
+		part, err := w.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := writeBatchPart(part, br); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	// The batch is shared by many callers, none of which may cancel it.
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, batch.url, &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "multipart/mixed; boundary="+w.Boundary())
+	req.Header.Set("User-Agent", batch.requests[0].req.Header.Get("User-Agent"))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Printf("[DEBUG] Batch request to %s failed: %s", batch.url, resp.Status)
+		return failedBatchResponses(resp, batch.requests)
+	}
+
+	return readBatchResponse(resp, batch.requests)
+}
+
+// failedBatchResponses answers every request in the batch with the error the
+// batch request failed with. Each request is then retried, or fails, as it
+// would have had it been sent on its own.
+func failedBatchResponses(resp *http.Response, requests []*batchedRequest) ([]*http.Response, error) {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading failed batch response: %s", err)
+	}
+
+	resps := make([]*http.Response, len(requests))
+	for i, br := range requests {
+		resps[i] = &http.Response{
+			Status:        resp.Status,
+			StatusCode:    resp.StatusCode,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        resp.Header.Clone(),
+			Body:          ioutil.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       br.req,
+		}
+	}
+
+	return resps, nil
+}
+
+func writeBatchPart(w io.Writer, br *batchedRequest) error {
+	if _, err := fmt.Fprintf(w, "%s %s HTTP/1.1\r\n", br.req.Method, br.req.URL.RequestURI()); err != nil {
+		return err
+	}
+
+	if len(br.body) > 0 {
+		if _, err := fmt.Fprintf(w, "Content-Type: %s\r\nContent-Length: %d\r\n", br.req.Header.Get("Content-Type"), len(br.body)); err != nil {
+			return err
+		}
+	}
+
+	if _, err := io.WriteString(w, "\r\n"); err != nil {
+		return err
+	}
+
+	_, err := w.Write(br.body)
+	return err
+}
+
+func readBatchResponse(resp *http.Response, requests []*batchedRequest) ([]*http.Response, error) {
+	_, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing batch response content type: %s", err)
+	}
+
+	resps := make([]*http.Response, len(requests))
+
+	r := multipart.NewReader(resp.Body, params["boundary"])
+	for i := 0; ; i++ {
+		part, err := r.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading batch response: %s", err)
+		}
+
+		// Responses are matched by the Content-ID of their request, and fall
+		// back to the order of the parts.
+		idx := i
+		contentID := strings.Trim(part.Header.Get("Content-ID"), "<>")
+		if n, err := strconv.Atoi(strings.TrimPrefix(contentID, "response-item")); err == nil {
+			idx = n
+		}
+		if idx < 0 || idx >= len(requests) {
+			return nil, fmt.Errorf("unexpected part %q in batch response", contentID)
+		}
+
+		partResp, err := http.ReadResponse(bufio.NewReader(part), requests[idx].req)
+		if err != nil {
+			return nil, fmt.Errorf("error reading batch response part %q: %s", contentID, err)
+		}
+
+		// the part can only be read until the next one is requested
+		partBody, err := ioutil.ReadAll(partResp.Body)
+		partResp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading batch response part %q: %s", contentID, err)
+		}
+		partResp.Body = ioutil.NopCloser(bytes.NewReader(partBody))
+
+		resps[idx] = partResp
+	}
+
+	for i, r := range resps {
+		if r == nil {
+			return nil, fmt.Errorf("batch response is missing a response for %s %s", requests[i].req.Method, requests[i].req.URL)
+		}
+	}
+
+	return resps, nil
+}
+
+// directoryBatchURL returns the URL of the batch endpoint for the request, and
+// whether the request can be batched.
+func directoryBatchURL(req *http.Request) (string, bool) {
+	re, ok := batchableDirectoryRequests[req.Method]
+	if !ok {
+		return "", false
+	}
+
+	// custom endpoints may prefix the API path
+	idx := strings.Index(req.URL.Path, "/"+directoryApiPath)
+	if idx < 0 || !re.MatchString(req.URL.Path[idx+len(directoryApiPath)+1:]) {
+		return "", false
+	}
+
+	batchURL := *req.URL
+	batchURL.Path = req.URL.Path[:idx+1] + directoryBatchApiPath
+	batchURL.RawPath = ""
+	batchURL.RawQuery = ""
+
+	return batchURL.String(), true
+}
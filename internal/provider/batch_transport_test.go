@@ -0,0 +1,354 @@
+package googleworkspace
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	directory "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/googleapi"
+)
+
+// testBatchServer answers batch requests by echoing the email of each inserted
+// member, and fails deleting any alias. Other requests are answered directly.
+func testBatchServer(t *testing.T, batches *[]int, direct *[]string) *httptest.Server {
+	var mutex sync.Mutex
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if !strings.HasSuffix(r.URL.Path, "/"+directoryBatchApiPath) {
+			*direct = append(*direct, r.Method+" "+r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"email": "direct@example.com"}`))
+			return
+		}
+
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			t.Errorf("error parsing batch content type: %s", err)
+		}
+
+		var respBody bytes.Buffer
+		mw := multipart.NewWriter(&respBody)
+
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		count := 0
+		for {
+			part, err := mr.NextPart()
+			if err != nil {
+				break
+			}
+			count++
+
+			req, err := http.ReadRequest(bufio.NewReader(part))
+			if err != nil {
+				t.Errorf("error reading batched request: %s", err)
+				continue
+			}
+
+			header := textproto.MIMEHeader{}
+			header.Set("Content-Type", "application/http")
+			header.Set("Content-ID", "<response-"+strings.Trim(part.Header.Get("Content-ID"), "<>")+">")
+			pw, _ := mw.CreatePart(header)
+
+			if req.Method == http.MethodDelete {
+				body := `{"error": {"code": 404, "message": "Resource Not Found: alias"}}`
+				fmt.Fprintf(pw, "HTTP/1.1 404 Not Found\r\nContent-Type: application/json\r\nContent-Length: %d\r\n\r\n%s", len(body), body)
+				continue
+			}
+
+			var member directory.Member
+			if err := json.NewDecoder(req.Body).Decode(&member); err != nil {
+				t.Errorf("error decoding batched request body: %s", err)
+			}
+			body, _ := json.Marshal(&directory.Member{Email: member.Email, Role: "MEMBER"})
+			fmt.Fprintf(pw, "HTTP/1.1 200 OK\r\nContent-Type: application/json\r\nContent-Length: %d\r\n\r\n%s", len(body), body)
+		}
+		mw.Close()
+
+		*batches = append(*batches, count)
+
+		w.Header().Set("Content-Type", "multipart/mixed; boundary="+mw.Boundary())
+		w.Write(respBody.Bytes())
+	}))
+}
+
+func testBatchingConfig(server *httptest.Server) *apiClient {
+	config := &apiClient{
+		client:                  server.Client(),
+		DirectoryCustomEndpoint: server.URL + "/directory",
+	}
+	config.directoryBatcher = newBatchTransport(server.Client().Transport, &batchingConfig{
+		SendAfter: 50 * time.Millisecond,
+	})
+
+	return config
+}
+
+func TestBatchTransport_coalescesMutations(t *testing.T) {
+	var batches []int
+	var direct []string
+	server := testBatchServer(t, &batches, &direct)
+	defer server.Close()
+
+	directoryService, diags := testBatchingConfig(server).NewDirectoryService()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var wg sync.WaitGroup
+	members := make([]*directory.Member, 5)
+	errs := make([]error, 6)
+	for i := range members {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			email := fmt.Sprintf("member-%d@example.com", i)
+			members[i], errs[i] = directoryService.Members.Insert("group", &directory.Member{Email: email}).Do()
+		}(i)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errs[5] = directoryService.Users.Aliases.Delete("user", "alias@example.com").Do()
+	}()
+	wg.Wait()
+
+	if len(batches) != 1 || batches[0] != 6 {
+		t.Fatalf("expected a single batch of 6 requests, got %v", batches)
+	}
+	if len(direct) != 0 {
+		t.Fatalf("expected no direct requests, got %v", direct)
+	}
+
+	for i, member := range members {
+		if errs[i] != nil {
+			t.Fatalf("unexpected error inserting member %d: %s", i, errs[i])
+		}
+		if expected := fmt.Sprintf("member-%d@example.com", i); member.Email != expected {
+			t.Errorf("expected member %d to be %q, got %q", i, expected, member.Email)
+		}
+	}
+
+	if gerr, ok := errs[5].(*googleapi.Error); !ok || gerr.Code != 404 {
+		t.Fatalf("expected a 404 deleting the alias, got %v", errs[5])
+	}
+}
+
+// A batch that fails as a whole is retried by each of its requests.
+func TestBatchTransport_batchUnavailable(t *testing.T) {
+	var batches []int
+	var direct []string
+	batchServer := testBatchServer(t, &batches, &direct)
+	defer batchServer.Close()
+
+	var mutex sync.Mutex
+	failed := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		unavailable := failed == 0 && strings.HasSuffix(r.URL.Path, "/"+directoryBatchApiPath)
+		if unavailable {
+			failed++
+		}
+		mutex.Unlock()
+
+		if unavailable {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error": {"code": 503, "message": "The service is currently unavailable."}}`))
+			return
+		}
+
+		batchServer.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	config := testBatchingConfig(server)
+	config.RetryPolicy = &retryPolicy{
+		MaxElapsedTime: 10 * time.Second,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+	}
+
+	directoryService, diags := config.NewDirectoryService()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var wg sync.WaitGroup
+	members := make([]*directory.Member, 5)
+	errs := make([]error, 5)
+	for i := range members {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			email := fmt.Sprintf("member-%d@example.com", i)
+			members[i], errs[i] = directoryService.Members.Insert("group", &directory.Member{Email: email}).Do()
+		}(i)
+	}
+	wg.Wait()
+
+	if failed != 1 {
+		t.Fatalf("expected a single failed batch, got %d", failed)
+	}
+
+	for i, member := range members {
+		if errs[i] != nil {
+			t.Fatalf("unexpected error inserting member %d: %s", i, errs[i])
+		}
+		if expected := fmt.Sprintf("member-%d@example.com", i); member.Email != expected {
+			t.Errorf("expected member %d to be %q, got %q", i, expected, member.Email)
+		}
+	}
+
+	if len(batches) != 1 || batches[0] != 5 {
+		t.Fatalf("expected the requests to be retried in a single batch of 5, got %v", batches)
+	}
+}
+
+func TestBatchTransport_singleRequestSentDirectly(t *testing.T) {
+	var batches []int
+	var direct []string
+	server := testBatchServer(t, &batches, &direct)
+	defer server.Close()
+
+	directoryService, diags := testBatchingConfig(server).NewDirectoryService()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if _, err := directoryService.Members.Insert("group", &directory.Member{Email: "member@example.com"}).Do(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := directoryService.Groups.Get("group").Do(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(batches) != 0 {
+		t.Fatalf("expected no batches, got %v", batches)
+	}
+
+	expected := []string{
+		"POST /directory/admin/directory/v1/groups/group/members",
+		"GET /directory/admin/directory/v1/groups/group",
+	}
+	if strings.Join(direct, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected direct requests %v, got %v", expected, direct)
+	}
+}
+
+func TestBatchTransport_cancelledRequest(t *testing.T) {
+	var batches []int
+	var direct []string
+	server := testBatchServer(t, &batches, &direct)
+	defer server.Close()
+
+	directoryService, diags := testBatchingConfig(server).NewDirectoryService()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := directoryService.Members.Insert("group", &directory.Member{}).Context(ctx).Do(); err == nil {
+		t.Fatalf("expected error, but got nil")
+	}
+}
+
+func TestDirectoryBatchURL(t *testing.T) {
+	cases := map[string]struct {
+		method   string
+		url      string
+		expected string
+	}{
+		"insert member": {
+			method:   http.MethodPost,
+			url:      "https://admin.googleapis.com/admin/directory/v1/groups/group/members?alt=json",
+			expected: "https://admin.googleapis.com/batch/admin/directory_v1",
+		},
+		"update member": {
+			method:   http.MethodPut,
+			url:      "https://admin.googleapis.com/admin/directory/v1/groups/group/members/member",
+			expected: "https://admin.googleapis.com/batch/admin/directory_v1",
+		},
+		"delete group alias": {
+			method:   http.MethodDelete,
+			url:      "https://admin.googleapis.com/admin/directory/v1/groups/group/aliases/alias",
+			expected: "https://admin.googleapis.com/batch/admin/directory_v1",
+		},
+		"insert user alias with custom endpoint": {
+			method:   http.MethodPost,
+			url:      "https://proxy.example.com/google/admin/directory/v1/users/user/aliases",
+			expected: "https://proxy.example.com/google/batch/admin/directory_v1",
+		},
+		"get member": {
+			method: http.MethodGet,
+			url:    "https://admin.googleapis.com/admin/directory/v1/groups/group/members/member",
+		},
+		"insert group": {
+			method: http.MethodPost,
+			url:    "https://admin.googleapis.com/admin/directory/v1/groups",
+		},
+		"delete user": {
+			method: http.MethodDelete,
+			url:    "https://admin.googleapis.com/admin/directory/v1/users/user",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, tc.url, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			batchURL, ok := directoryBatchURL(req)
+			if ok != (tc.expected != "") {
+				t.Fatalf("expected batchable to be %t, got %t", tc.expected != "", ok)
+			}
+			if batchURL != tc.expected {
+				t.Fatalf("expected batch url %q, got %q", tc.expected, batchURL)
+			}
+		})
+	}
+}
+
+func TestExpandBatchingConfig(t *testing.T) {
+	if config := expandBatchingConfig(nil); config != nil {
+		t.Errorf("expected batching to be disabled without a block, got %v", config)
+	}
+
+	if config := expandBatchingConfig([]interface{}{nil}); config == nil || config.SendAfter != defaultBatchingConfig().SendAfter {
+		t.Errorf("expected an empty block to enable batching with the defaults, got %v", config)
+	}
+
+	config := expandBatchingConfig([]interface{}{
+		map[string]interface{}{"enable_batching": true, "send_after": "2s"},
+	})
+	if config == nil || config.SendAfter != 2*time.Second {
+		t.Errorf("expected batching to be sent after 2s, got %v", config)
+	}
+
+	config = expandBatchingConfig([]interface{}{
+		map[string]interface{}{"enable_batching": false, "send_after": "2s"},
+	})
+	if config != nil {
+		t.Errorf("expected batching to be disabled, got %v", config)
+	}
+}
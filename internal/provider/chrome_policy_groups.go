@@ -0,0 +1,126 @@
+package googleworkspace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"google.golang.org/api/chromepolicy/v1"
+	"google.golang.org/api/googleapi"
+)
+
+// chromePolicyGroupsService calls customers.policies.groups of the Chrome
+// Policy API, which the chromepolicy/v1 client of google.golang.org/api
+// v0.49.0 doesn't have. The requests are sent with the same client, and to the
+// same endpoint, as those of the Chrome Policy service.
+type chromePolicyGroupsService struct {
+	client    *http.Client
+	basePath  string
+	userAgent string
+}
+
+type chromePolicyModifyGroupPolicyRequest struct {
+	PolicyTargetKey *chromepolicy.GoogleChromePolicyV1PolicyTargetKey `json:"policyTargetKey"`
+	PolicyValue     *chromepolicy.GoogleChromePolicyV1PolicyValue     `json:"policyValue"`
+	UpdateMask      string                                            `json:"updateMask"`
+}
+
+type chromePolicyDeleteGroupPolicyRequest struct {
+	PolicyTargetKey *chromepolicy.GoogleChromePolicyV1PolicyTargetKey `json:"policyTargetKey"`
+	PolicySchema    string                                            `json:"policySchema"`
+}
+
+// chromePolicyGroupPriorityOrdering is the order in which the policies of
+// groups apply to an app, from the highest priority. The request to list it
+// has no group IDs.
+type chromePolicyGroupPriorityOrdering struct {
+	PolicyTargetKey *chromepolicy.GoogleChromePolicyV1PolicyTargetKey `json:"policyTargetKey"`
+	PolicyNamespace string                                            `json:"policyNamespace"`
+	PolicySchema    string                                            `json:"policySchema,omitempty"`
+	GroupIds        []string                                          `json:"groupIds,omitempty"`
+}
+
+func (c *apiClient) NewChromePolicyGroupsService() (*chromePolicyGroupsService, diag.Diagnostics) {
+	// the base path honours the custom endpoint
+	chromePolicyService, diags := c.NewChromePolicyService()
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	log.Printf("[INFO] Instantiating Google Admin Chrome Policy Groups service")
+
+	// the same user agent as that of the generated clients
+	userAgent := googleapi.UserAgent
+	if chromePolicyService.UserAgent != "" {
+		userAgent += " " + chromePolicyService.UserAgent
+	}
+
+	return &chromePolicyGroupsService{
+		client:    c.apiHTTPClient(chromePolicyApi),
+		basePath:  chromePolicyService.BasePath,
+		userAgent: userAgent,
+	}, diags
+}
+
+// BatchModify sets the fields of the policies of groups that are named by
+// the update mask of each request.
+func (s *chromePolicyGroupsService) BatchModify(ctx context.Context, customer string, requests []*chromePolicyModifyGroupPolicyRequest) error {
+	return s.do(ctx, customer, "batchModify", map[string]interface{}{"requests": requests}, nil)
+}
+
+// BatchDelete removes the policies of groups.
+func (s *chromePolicyGroupsService) BatchDelete(ctx context.Context, customer string, requests []*chromePolicyDeleteGroupPolicyRequest) error {
+	return s.do(ctx, customer, "batchDelete", map[string]interface{}{"requests": requests}, nil)
+}
+
+func (s *chromePolicyGroupsService) ListGroupPriorityOrdering(ctx context.Context, customer string, req *chromePolicyGroupPriorityOrdering) (*chromePolicyGroupPriorityOrdering, error) {
+	var resp chromePolicyGroupPriorityOrdering
+	if err := s.do(ctx, customer, "listGroupPriorityOrdering", req, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (s *chromePolicyGroupsService) UpdateGroupPriorityOrdering(ctx context.Context, customer string, req *chromePolicyGroupPriorityOrdering) error {
+	return s.do(ctx, customer, "updateGroupPriorityOrdering", req, nil)
+}
+
+// do posts the request to the method of customers.policies.groups, and
+// decodes the response into resp, unless it's nil.
+func (s *chromePolicyGroupsService) do(ctx context.Context, customer, method string, body, resp interface{}) error {
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%sv1/%s/policies/groups:%s", s.basePath, customer, method)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", s.userAgent)
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer googleapi.CloseBody(res)
+
+	// errors are returned as those of the generated clients are
+	if err := googleapi.CheckResponse(res); err != nil {
+		return err
+	}
+
+	if resp == nil {
+		return nil
+	}
+
+	return json.NewDecoder(res.Body).Decode(resp)
+}
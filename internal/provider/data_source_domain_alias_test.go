@@ -5,7 +5,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -17,11 +16,11 @@ func TestAccDataSourceDomainAlias(t *testing.T) {
 		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
 	}
 
-	domainAlias := fmt.Sprintf("tf-test-%s.com", acctest.RandString(10))
+	domainAlias := fmt.Sprintf("tf-test-%s.com", randString(t, 10))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceDomainAlias(domainName, domainAlias),
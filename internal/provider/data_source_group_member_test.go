@@ -5,7 +5,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -20,14 +19,14 @@ func TestAccDataSourceGroupMember_withId(t *testing.T) {
 
 	testGroupVals := map[string]interface{}{
 		"domainName": domainName,
-		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"groupEmail": fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"password":   acctest.RandString(10),
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"groupEmail": fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
 	}
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceGroupMember_withId(testGroupVals),
@@ -55,14 +54,14 @@ func TestAccDataSourceGroupMember_withEmail(t *testing.T) {
 
 	testGroupVals := map[string]interface{}{
 		"domainName": domainName,
-		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"groupEmail": fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"password":   acctest.RandString(10),
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"groupEmail": fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
 	}
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceGroupMember_withEmail(testGroupVals),
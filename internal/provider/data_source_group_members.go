@@ -0,0 +1,197 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataSourceGroupMembers() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Group Members data source in the Terraform Googleworkspace provider. Group Members lists the " +
+			"members of a group, optionally expanding the members of nested groups.",
+
+		ReadContext: dataSourceGroupMembersRead,
+
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Description: "Identifies the group in the API request. The value can be the group's email address, " +
+					"group alias, or the unique group ID.",
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"roles": {
+				Description: "Lists only the members of the group with these roles. Acceptable values are " +
+					"`MANAGER`, `MEMBER` and `OWNER`. When `recursive` is set, this filters the direct members of " +
+					"the group, and the nested groups that match are expanded whatever the roles of their members.",
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"MANAGER", "MEMBER", "OWNER"}, false),
+				},
+			},
+			"recursive": {
+				Description: "Whether the members of nested groups are listed in place of the groups, so `members` " +
+					"is the effective set of users of the group. Users that are members through several groups " +
+					"are listed once, with the role and delivery settings of the first membership found, " +
+					"preferring direct memberships.",
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"members": {
+				Description: "The members of the group.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The unique ID of the group member.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"email": {
+							Description: "The member's email address.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"role": {
+							Description: "The member's role in the group.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "The type of group member.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"status": {
+							Description: "Status of member.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"delivery_settings": {
+							Description: "Defines mail delivery preferences of member.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGroupMembersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	groupId := d.Get("group_id").(string)
+	log.Printf("[DEBUG] Listing Group Members of Group %s", groupId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	membersService, diags := GetMembersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	listCall := membersService.List(groupId).Context(ctx)
+
+	roles := listOfInterfacestoStrings(d.Get("roles").(*schema.Set).List())
+	if len(roles) > 0 {
+		listCall.Roles(strings.Join(roles, ","))
+	}
+
+	var members []*directory.Member
+	err := listCall.Pages(ctx, func(resp *directory.Members) error {
+		members = append(members, resp.Members...)
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("recursive").(bool) {
+		members, err = expandGroupMembers(ctx, membersService, groupId, members)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	var result []interface{}
+	for _, member := range members {
+		result = append(result, map[string]interface{}{
+			"id":                member.Id,
+			"email":             member.Email,
+			"role":              member.Role,
+			"type":              member.Type,
+			"status":            member.Status,
+			"delivery_settings": member.DeliverySettings,
+		})
+	}
+
+	if err := d.Set("members", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("groups/%s", groupId))
+
+	log.Printf("[DEBUG] Finished listing %d Group Members of Group %s", len(members), groupId)
+
+	return diags
+}
+
+// expandGroupMembers replaces the groups among the members with their own
+// members, breadth first so direct memberships are found first. Every group is
+// only expanded once, as group memberships may be cyclic.
+func expandGroupMembers(ctx context.Context, membersService *directory.MembersService, groupId string, members []*directory.Member) ([]*directory.Member, error) {
+	var result []*directory.Member
+
+	expanded := map[string]bool{strings.ToLower(groupId): true}
+	found := map[string]bool{}
+
+	queue := members
+	for len(queue) > 0 {
+		member := queue[0]
+		queue = queue[1:]
+
+		if member.Type != "GROUP" {
+			if !found[member.Id] {
+				found[member.Id] = true
+				result = append(result, member)
+			}
+			continue
+		}
+
+		if expanded[member.Id] || expanded[strings.ToLower(member.Email)] {
+			continue
+		}
+		expanded[member.Id] = true
+		expanded[strings.ToLower(member.Email)] = true
+
+		log.Printf("[DEBUG] Expanding the members of nested Group %s", member.Email)
+		nested, err := listGroupMembers(ctx, membersService, member.Id)
+		if err != nil {
+			return nil, fmt.Errorf("error listing the members of nested group %s: %s", member.Email, err)
+		}
+
+		queue = append(queue, nested...)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,167 @@
+package googleworkspace
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceGroupMembers_basic(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testGroupVals := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"groupEmail": fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGroupMembers(testGroupVals),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.googleworkspace_group_members.direct", "members.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.googleworkspace_group_members.direct", "members.*",
+						map[string]string{
+							"email": Nprintf("%{userEmail}-1@%{domainName}", testGroupVals),
+							"role":  "OWNER",
+							"type":  "USER",
+						}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.googleworkspace_group_members.direct", "members.*",
+						map[string]string{
+							"email": Nprintf("%{groupEmail}-sub@%{domainName}", testGroupVals),
+							"type":  "GROUP",
+						}),
+					resource.TestCheckResourceAttr("data.googleworkspace_group_members.owners", "members.#", "1"),
+					resource.TestCheckResourceAttr("data.googleworkspace_group_members.owners", "members.0.email",
+						Nprintf("%{userEmail}-1@%{domainName}", testGroupVals)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceGroupMembers_recursive(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testGroupVals := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"groupEmail": fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGroupMembers(testGroupVals),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.googleworkspace_group_members.recursive", "members.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.googleworkspace_group_members.recursive", "members.*",
+						map[string]string{
+							"email": Nprintf("%{userEmail}-1@%{domainName}", testGroupVals),
+							"role":  "OWNER",
+						}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.googleworkspace_group_members.recursive", "members.*",
+						map[string]string{
+							"email": Nprintf("%{userEmail}-2@%{domainName}", testGroupVals),
+							"type":  "USER",
+						}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceGroupMembers(testGroupVals map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_group" "my-group" {
+  email = "%{groupEmail}@%{domainName}"
+}
+
+resource "googleworkspace_group" "my-sub-group" {
+  email = "%{groupEmail}-sub@%{domainName}"
+}
+
+resource "googleworkspace_user" "my-new-user-1" {
+  primary_email = "%{userEmail}-1@%{domainName}"
+  password = "%{password}"
+
+  name {
+    family_name = "Scott"
+    given_name = "Michael"
+  }
+}
+
+resource "googleworkspace_user" "my-new-user-2" {
+  primary_email = "%{userEmail}-2@%{domainName}"
+  password = "%{password}"
+
+  name {
+    family_name = "Schrute"
+    given_name = "Dwight"
+  }
+}
+
+resource "googleworkspace_group_members" "my-group-members" {
+  group_id = googleworkspace_group.my-group.id
+
+  members {
+    email = googleworkspace_user.my-new-user-1.primary_email
+    role = "OWNER"
+  }
+
+  members {
+    email = googleworkspace_group.my-sub-group.email
+    type = "GROUP"
+  }
+}
+
+resource "googleworkspace_group_members" "my-sub-group-members" {
+  group_id = googleworkspace_group.my-sub-group.id
+
+  members {
+    email = googleworkspace_user.my-new-user-1.primary_email
+  }
+
+  members {
+    email = googleworkspace_user.my-new-user-2.primary_email
+  }
+}
+
+data "googleworkspace_group_members" "direct" {
+  group_id = googleworkspace_group_members.my-group-members.group_id
+}
+
+data "googleworkspace_group_members" "owners" {
+  group_id = googleworkspace_group_members.my-group-members.group_id
+  roles = ["OWNER"]
+}
+
+data "googleworkspace_group_members" "recursive" {
+  group_id = googleworkspace_group_members.my-group-members.group_id
+  recursive = true
+
+  depends_on = [googleworkspace_group_members.my-sub-group-members]
+}
+`, testGroupVals)
+}
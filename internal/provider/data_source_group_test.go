@@ -5,7 +5,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -20,12 +19,12 @@ func TestAccDataSourceGroup_withId(t *testing.T) {
 
 	testGroupVals := map[string]interface{}{
 		"domainName": domainName,
-		"email":      fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+		"email":      fmt.Sprintf("tf-test-%s", randString(t, 10)),
 	}
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceGroup_withId(testGroupVals),
@@ -51,13 +50,13 @@ func TestAccDataSourceGroup_withEmail(t *testing.T) {
 
 	testGroupVals := map[string]interface{}{
 		"domainName": domainName,
-		"email":      fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"password":   acctest.RandString(10),
+		"email":      fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
 	}
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceGroup_withEmail(testGroupVals),
@@ -0,0 +1,136 @@
+package googleworkspace
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataSourceGroups() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Groups data source in the Terraform Googleworkspace provider. Groups lists the groups of the " +
+			"customer, or of a domain, that match the filters.",
+
+		ReadContext: dataSourceGroupsRead,
+
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Description: "A query string for searching group fields, such as `email:sales-*`. For more " +
+					"information on constructing group queries, see " +
+					"[Search for Groups](https://developers.google.com/admin-sdk/directory/v1/guides/search-groups).",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"domain": {
+				Description: "The domain name to list the groups of. Defaults to all of the customer's groups.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"user_key": {
+				Description: "Lists only the groups that the user or group is a direct member of. The value can be " +
+					"the email address or the unique ID of the user or group.",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"groups": {
+				Description: "The groups that match the filters.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					// Generate the schema of the listed groups from the resource
+					Schema: datasourceSchemaFromResourceSchema(resourceGroup().Schema),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGroupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	groupsService, diags := GetGroupsService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	listCall := groupsService.List().MaxResults(200)
+
+	domain := d.Get("domain").(string)
+	userKey := d.Get("user_key").(string)
+
+	// the groups of a domain or of a member are listed instead of all of the
+	// customer's groups
+	if domain != "" {
+		listCall.Domain(domain)
+	}
+	if userKey != "" {
+		listCall.UserKey(userKey)
+	}
+	if domain == "" && userKey == "" {
+		listCall.Customer(client.Customer)
+	}
+
+	query := d.Get("query").(string)
+	if query != "" {
+		listCall.Query(query)
+	}
+
+	log.Printf("[DEBUG] Listing Groups with query %q", query)
+
+	var groups []interface{}
+	err := listCall.Pages(ctx, func(resp *directory.Groups) error {
+		for _, group := range resp.Groups {
+			groups = append(groups, flattenGroup(group))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("groups", groups); err != nil {
+		return diag.FromErr(err)
+	}
+
+	switch {
+	case userKey != "":
+		d.SetId(userKey)
+	case domain != "":
+		d.SetId(domain)
+	default:
+		d.SetId(client.Customer)
+	}
+
+	log.Printf("[DEBUG] Finished listing %d Groups", len(groups))
+
+	return diags
+}
+
+// flattenGroup flattens the group into the attributes of the group resource.
+func flattenGroup(group *directory.Group) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                   group.Id,
+		"email":                group.Email,
+		"name":                 group.Name,
+		"description":          group.Description,
+		"admin_created":        group.AdminCreated,
+		"direct_members_count": int(group.DirectMembersCount),
+		"aliases":              group.Aliases,
+		"non_editable_aliases": group.NonEditableAliases,
+		"etag":                 group.Etag,
+	}
+}
@@ -0,0 +1,121 @@
+package googleworkspace
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceGroups_query(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testGroupVals := map[string]interface{}{
+		"domainName": domainName,
+		"groupEmail": fmt.Sprintf("tf-test-%s", randString(t, 10)),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGroups_query(testGroupVals),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.googleworkspace_groups.my-groups", "groups.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.googleworkspace_groups.my-groups", "groups.*",
+						map[string]string{
+							"email":       Nprintf("%{groupEmail}-1@%{domainName}", testGroupVals),
+							"description": "Sales",
+						}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.googleworkspace_groups.my-groups", "groups.*",
+						map[string]string{
+							"email": Nprintf("%{groupEmail}-2@%{domainName}", testGroupVals),
+						}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceGroups_userKey(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testGroupVals := map[string]interface{}{
+		"domainName": domainName,
+		"groupEmail": fmt.Sprintf("tf-test-%s", randString(t, 10)),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGroups_userKey(testGroupVals),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.googleworkspace_groups.my-groups", "groups.#", "1"),
+					resource.TestCheckResourceAttr("data.googleworkspace_groups.my-groups", "groups.0.email",
+						Nprintf("%{groupEmail}-parent@%{domainName}", testGroupVals)),
+					resource.TestCheckResourceAttr("data.googleworkspace_groups.my-groups", "groups.0.direct_members_count", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceGroups_query(testGroupVals map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_group" "my-group-1" {
+  email = "%{groupEmail}-1@%{domainName}"
+  description = "Sales"
+}
+
+resource "googleworkspace_group" "my-group-2" {
+  email = "%{groupEmail}-2@%{domainName}"
+}
+
+data "googleworkspace_groups" "my-groups" {
+  query = "email:%{groupEmail}-*"
+
+  depends_on = [
+    googleworkspace_group.my-group-1,
+    googleworkspace_group.my-group-2,
+  ]
+}
+`, testGroupVals)
+}
+
+func testAccDataSourceGroups_userKey(testGroupVals map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_group" "my-parent-group" {
+  email = "%{groupEmail}-parent@%{domainName}"
+}
+
+resource "googleworkspace_group" "my-group" {
+  email = "%{groupEmail}@%{domainName}"
+}
+
+resource "googleworkspace_group_member" "my-group-member" {
+  group_id = googleworkspace_group.my-parent-group.id
+  email = googleworkspace_group.my-group.email
+  type = "GROUP"
+}
+
+data "googleworkspace_groups" "my-groups" {
+  user_key = googleworkspace_group_member.my-group-member.email
+}
+`, testGroupVals)
+}
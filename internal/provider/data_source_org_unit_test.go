@@ -4,18 +4,17 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccDataSourceOrgUnit_withOrgUnitId(t *testing.T) {
 	t.Parallel()
 
-	ouName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	ouName := fmt.Sprintf("tf-test-%s", randString(t, 10))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceOrgUnit_withOrgUnitId(ouName),
@@ -31,11 +30,11 @@ func TestAccDataSourceOrgUnit_withOrgUnitId(t *testing.T) {
 func TestAccDataSourceOrgUnit_withOrgUnitPath(t *testing.T) {
 	t.Parallel()
 
-	ouName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	ouName := fmt.Sprintf("tf-test-%s", randString(t, 10))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceOrgUnit_withOrgUnitPath(ouName),
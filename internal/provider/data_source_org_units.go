@@ -0,0 +1,109 @@
+package googleworkspace
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataSourceOrgUnits() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "OrgUnits data source in the Terraform Googleworkspace provider. OrgUnits lists the " +
+			"organizational units below a parent organizational unit.",
+
+		ReadContext: dataSourceOrgUnitsRead,
+
+		Schema: map[string]*schema.Schema{
+			"org_unit_path": {
+				Description: "The full path, or the unique ID, of the organizational unit to list the organizational " +
+					"units below. Defaults to the root organizational unit.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "/",
+			},
+			"type": {
+				Description: "Which organizational units to list. Acceptable values are: " +
+					"`all`: All sub-organizational units. " +
+					"`allIncludingParent`: All sub-organizational units and the specified organizational unit. " +
+					"`children`: Only the immediate children of the specified organizational unit.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "all",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"all", "allIncludingParent",
+					"children"}, false)),
+			},
+			"org_units": {
+				Description: "The organizational units.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					// Generate the schema of the listed org units from the resource
+					Schema: datasourceSchemaFromResourceSchema(resourceOrgUnit().Schema),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceOrgUnitsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	orgUnitPath := d.Get("org_unit_path").(string)
+	listType := d.Get("type").(string)
+	log.Printf("[DEBUG] Listing OrgUnits of %s: %s", orgUnitPath, listType)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	orgUnitsService, diags := GetOrgUnitsService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	orgUnits, err := orgUnitsService.List(client.Customer).OrgUnitPath(orgUnitPath).Type(listType).Context(ctx).Do()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var result []interface{}
+	for _, orgUnit := range orgUnits.OrganizationUnits {
+		result = append(result, flattenOrgUnit(orgUnit))
+	}
+
+	if err := d.Set("org_units", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(orgUnitPath)
+
+	log.Printf("[DEBUG] Finished listing %d OrgUnits of %s", len(result), orgUnitPath)
+
+	return diags
+}
+
+// flattenOrgUnit flattens the org unit into the attributes of the org unit
+// resource.
+func flattenOrgUnit(orgUnit *directory.OrgUnit) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                   orgUnit.OrgUnitId,
+		"name":                 orgUnit.Name,
+		"description":          orgUnit.Description,
+		"etag":                 orgUnit.Etag,
+		"block_inheritance":    orgUnit.BlockInheritance,
+		"org_unit_id":          orgUnit.OrgUnitId,
+		"org_unit_path":        orgUnit.OrgUnitPath,
+		"parent_org_unit_id":   orgUnit.ParentOrgUnitId,
+		"parent_org_unit_path": orgUnit.ParentOrgUnitPath,
+	}
+}
@@ -0,0 +1,77 @@
+package googleworkspace
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceOrgUnits_basic(t *testing.T) {
+	t.Parallel()
+
+	ouName := fmt.Sprintf("tf-test-%s", randString(t, 10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceOrgUnits_basic(ouName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.googleworkspace_org_units.all", "org_units.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.googleworkspace_org_units.all", "org_units.*",
+						map[string]string{
+							"name":                 "grandchild",
+							"org_unit_path":        fmt.Sprintf("/%s/child/grandchild", ouName),
+							"parent_org_unit_path": fmt.Sprintf("/%s/child", ouName),
+							"description":          "Grandchild",
+							"block_inheritance":    "false",
+						}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.googleworkspace_org_units.all", "org_units.*",
+						map[string]string{
+							"org_unit_path": fmt.Sprintf("/%s", ouName),
+						}),
+					resource.TestCheckResourceAttr("data.googleworkspace_org_units.children", "org_units.#", "1"),
+					resource.TestCheckResourceAttr("data.googleworkspace_org_units.children", "org_units.0.name", "child"),
+					resource.TestCheckResourceAttrPair("data.googleworkspace_org_units.children", "org_units.0.org_unit_id",
+						"googleworkspace_org_unit.child", "org_unit_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceOrgUnits_basic(ouName string) string {
+	return fmt.Sprintf(`
+resource "googleworkspace_org_unit" "parent" {
+  name = "%s"
+  parent_org_unit_path = "/"
+}
+
+resource "googleworkspace_org_unit" "child" {
+  name = "child"
+  parent_org_unit_path = googleworkspace_org_unit.parent.org_unit_path
+}
+
+resource "googleworkspace_org_unit" "grandchild" {
+  name = "grandchild"
+  description = "Grandchild"
+  parent_org_unit_path = googleworkspace_org_unit.child.org_unit_path
+}
+
+data "googleworkspace_org_units" "all" {
+  org_unit_path = googleworkspace_org_unit.parent.org_unit_path
+  type = "allIncludingParent"
+
+  depends_on = [googleworkspace_org_unit.grandchild]
+}
+
+data "googleworkspace_org_units" "children" {
+  org_unit_path = googleworkspace_org_unit.parent.org_unit_path
+  type = "children"
+
+  depends_on = [googleworkspace_org_unit.grandchild]
+}
+`, ouName)
+}
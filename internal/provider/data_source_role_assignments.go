@@ -0,0 +1,107 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataSourceRoleAssignments() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "RoleAssignments data source in the Terraform Googleworkspace provider. RoleAssignments lists " +
+			"the role assignments of the customer, including those that aren't managed by Terraform.",
+
+		ReadContext: dataSourceRoleAssignmentsRead,
+
+		Schema: map[string]*schema.Schema{
+			"role_id": {
+				Description: "Lists only the assignments of the role with this ID.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"user_key": {
+				Description: "Lists only the assignments of the user. The value can be the user's primary email " +
+					"address, alias email address, or unique user ID.",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"role_assignments": {
+				Description: "The role assignments.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: datasourceSchemaFromResourceSchema(resourceRoleAssignment().Schema),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRoleAssignmentsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	roleAssignmentsService, diags := GetRoleAssignmentsService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	listCall := roleAssignmentsService.List(client.Customer).MaxResults(200)
+
+	roleId := d.Get("role_id").(string)
+	if roleId != "" {
+		listCall.RoleId(roleId)
+	}
+
+	userKey := d.Get("user_key").(string)
+	if userKey != "" {
+		listCall.UserKey(userKey)
+	}
+
+	log.Printf("[DEBUG] Listing RoleAssignments role:%s, user:%s", roleId, userKey)
+
+	var roleAssignments []interface{}
+	if err := listCall.Pages(ctx, func(resp *directory.RoleAssignments) error {
+		for _, ra := range resp.Items {
+			roleAssignments = append(roleAssignments, flattenRoleAssignment(ra))
+		}
+		return nil
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("role_assignments", roleAssignments); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", client.Customer, roleId, userKey))
+
+	log.Printf("[DEBUG] Finished listing %d RoleAssignments", len(roleAssignments))
+
+	return diags
+}
+
+func flattenRoleAssignment(ra *directory.RoleAssignment) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          strconv.FormatInt(ra.RoleAssignmentId, 10),
+		"role_id":     strconv.FormatInt(ra.RoleId, 10),
+		"etag":        ra.Etag,
+		"assigned_to": ra.AssignedTo,
+		"scope_type":  ra.ScopeType,
+		"org_unit_id": ra.OrgUnitId,
+	}
+}
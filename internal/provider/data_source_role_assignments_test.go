@@ -0,0 +1,75 @@
+package googleworkspace
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRoleAssignments(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	data := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceRoleAssignments(data),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.googleworkspace_role_assignments.user", "role_assignments.#", "1"),
+					resource.TestCheckResourceAttrPair("data.googleworkspace_role_assignments.user", "role_assignments.0.id",
+						"googleworkspace_role_assignment.test", "id"),
+					resource.TestCheckResourceAttrPair("data.googleworkspace_role_assignments.user", "role_assignments.0.role_id",
+						"data.googleworkspace_role.test", "id"),
+					resource.TestCheckResourceAttr("data.googleworkspace_role_assignments.user", "role_assignments.0.scope_type", "CUSTOMER"),
+					resource.TestCheckTypeSetElemAttrPair("data.googleworkspace_role_assignments.role", "role_assignments.*.assigned_to",
+						"googleworkspace_user.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceRoleAssignments(data map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_user" "test" {
+  primary_email = "%{userEmail}@%{domainName}"
+  password = "%{password}"
+
+  name {
+    family_name = "Scott"
+    given_name = "Michael"
+  }
+}
+
+data "googleworkspace_role" "test" {
+  name = "_GROUPS_ADMIN_ROLE"
+}
+
+resource "googleworkspace_role_assignment" "test" {
+  role_id = data.googleworkspace_role.test.id
+  assigned_to = googleworkspace_user.test.id
+}
+
+data "googleworkspace_role_assignments" "user" {
+  user_key = googleworkspace_role_assignment.test.assigned_to
+}
+
+data "googleworkspace_role_assignments" "role" {
+  role_id = googleworkspace_role_assignment.test.role_id
+}
+`, data)
+}
@@ -0,0 +1,84 @@
+package googleworkspace
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataSourceRoles() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Roles data source in the Terraform Googleworkspace provider. Roles lists every role of the " +
+			"customer, including the pre-defined system roles.",
+
+		ReadContext: dataSourceRolesRead,
+
+		Schema: map[string]*schema.Schema{
+			"roles": {
+				Description: "The roles of the customer.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: datasourceSchemaFromResourceSchema(resourceRole().Schema),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRolesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	rolesService, diags := GetRolesService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Listing Roles")
+
+	var roles []interface{}
+	if err := rolesService.List(client.Customer).Pages(ctx, func(resp *directory.Roles) error {
+		for _, role := range resp.Items {
+			roles = append(roles, flattenRole(role))
+		}
+		return nil
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("roles", roles); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(client.Customer)
+
+	log.Printf("[DEBUG] Finished listing %d Roles", len(roles))
+
+	return diags
+}
+
+func flattenRole(role *directory.Role) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                  strconv.FormatInt(role.RoleId, 10),
+		"name":                role.RoleName,
+		"description":         role.RoleDescription,
+		"privileges":          flattenRolePrivileges(role.RolePrivileges),
+		"is_system_role":      role.IsSystemRole,
+		"is_super_admin_role": role.IsSuperAdminRole,
+		"etag":                role.Etag,
+	}
+}
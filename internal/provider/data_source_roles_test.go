@@ -0,0 +1,39 @@
+package googleworkspace
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRoles(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceRoles(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.googleworkspace_roles.test", "roles.*",
+						map[string]string{
+							"name":           "_GROUPS_ADMIN_ROLE",
+							"is_system_role": "true",
+							"privileges.#":   "6",
+						}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.googleworkspace_roles.test", "roles.*",
+						map[string]string{
+							"is_super_admin_role": "true",
+						}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceRoles() string {
+	return `
+data "googleworkspace_roles" "test" {}
+`
+}
@@ -4,16 +4,15 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccDataSourceSchema_withId(t *testing.T) {
-	schemaName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	schemaName := fmt.Sprintf("tf-test-%s", randString(t, 10))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceSchema_withId(schemaName),
@@ -31,11 +30,11 @@ func TestAccDataSourceSchema_withId(t *testing.T) {
 }
 
 func TestAccDataSourceSchema_withName(t *testing.T) {
-	schemaName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	schemaName := fmt.Sprintf("tf-test-%s", randString(t, 10))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceSchema_withName(schemaName),
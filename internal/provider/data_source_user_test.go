@@ -5,7 +5,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -20,13 +19,13 @@ func TestAccDataSourceUser_withId(t *testing.T) {
 
 	testUserVals := map[string]interface{}{
 		"domainName": domainName,
-		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"password":   acctest.RandString(10),
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
 	}
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceUser_withId(testUserVals),
@@ -56,13 +55,13 @@ func TestAccDataSourceUser_withEmail(t *testing.T) {
 
 	testUserVals := map[string]interface{}{
 		"domainName": domainName,
-		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"password":   acctest.RandString(10),
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
 	}
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceUser_withEmail(testUserVals),
@@ -0,0 +1,230 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func dataSourceUsers() *schema.Resource {
+	// Generate the schema of the listed users from the resource
+	userSchema := datasourceSchemaFromResourceSchema(resourceUser().Schema)
+
+	// the password is never returned by the API
+	delete(userSchema, "password")
+	delete(userSchema, "hash_function")
+
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Users data source in the Terraform Googleworkspace provider. Users lists the users of the " +
+			"customer, or of a domain, that match the filters.",
+
+		ReadContext: dataSourceUsersRead,
+
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Description: "A query string for searching user fields, such as `orgDepartment='Sales'` or " +
+					"`isSuspended=false`. Custom fields can be searched as `schemaName.fieldName=value`. For more " +
+					"information on constructing user queries, see " +
+					"[Search for Users](https://developers.google.com/admin-sdk/directory/v1/guides/search-users).",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"domain": {
+				Description: "The domain name to list the users of. Defaults to all of the customer's users.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"org_unit_path": {
+				Description: "The full path of the org unit to list the users of, such as `/Sales`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"show_deleted": {
+				Description: "Whether to list deleted users instead of active ones.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"projection": {
+				Description: "What subset of fields to fetch for each user. Acceptable values are: " +
+					"`basic`: Do not include any custom fields for the user. " +
+					"`custom`: Include custom fields from schemas requested in `custom_field_mask`. " +
+					"`full`: Include all fields associated with this user.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "full",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"basic", "custom", "full"},
+					false)),
+			},
+			"custom_field_mask": {
+				Description: "A comma-separated list of schema names. All fields from these schemas are fetched. " +
+					"This should only be set when `projection` is `custom`.",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"users": {
+				Description: "The users that match the filters.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: userSchema,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	listCall := usersService.List().Projection(d.Get("projection").(string)).MaxResults(500)
+
+	// the users of a domain are listed instead of all of the customer's users
+	domain := d.Get("domain").(string)
+	if domain != "" {
+		listCall.Domain(domain)
+	} else {
+		listCall.Customer(client.Customer)
+	}
+
+	var query []string
+	if v := d.Get("query").(string); v != "" {
+		query = append(query, v)
+	}
+	if v := d.Get("org_unit_path").(string); v != "" {
+		query = append(query, fmt.Sprintf("orgUnitPath=%s", quoteUsersQueryValue(v)))
+	}
+	if len(query) > 0 {
+		listCall.Query(strings.Join(query, " "))
+	}
+
+	if d.Get("show_deleted").(bool) {
+		listCall.ShowDeleted("true")
+	}
+
+	if v := d.Get("custom_field_mask").(string); v != "" {
+		listCall.CustomFieldMask(v)
+	}
+
+	log.Printf("[DEBUG] Listing Users with query %q", strings.Join(query, " "))
+
+	var users []*directory.User
+	err := listCall.Pages(ctx, func(resp *directory.Users) error {
+		users = append(users, resp.Users...)
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// schema definitions are shared by the users, so they're only retrieved once
+	schemaDefs := map[string]*directory.Schema{}
+
+	var result []interface{}
+	for _, user := range users {
+		customSchemas := []map[string]interface{}{}
+		if len(user.CustomSchemas) > 0 {
+			customSchemas, diags = flattenCustomSchemasWithDefinitions(user.CustomSchemas, client, schemaDefs)
+			if diags.HasError() {
+				return diags
+			}
+		}
+
+		result = append(result, flattenUser(user, customSchemas))
+	}
+
+	if err := d.Set("users", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the users listed depend on the filters, so they're part of the id
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join([]string{
+		client.Customer,
+		domain,
+		strings.Join(query, " "),
+		strconv.FormatBool(d.Get("show_deleted").(bool)),
+	}, "/"))))
+
+	log.Printf("[DEBUG] Finished listing %d Users", len(users))
+
+	return diags
+}
+
+// quoteUsersQueryValue quotes the value for the query of users, escaping the
+// quotes and backslashes within it, as org unit names may contain them.
+func quoteUsersQueryValue(v string) string {
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `'`, `\'`)
+
+	return "'" + v + "'"
+}
+
+// flattenUser flattens the user into the attributes of the user resource,
+// other than those that are never returned by the API.
+func flattenUser(user *directory.User, customSchemas []map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                                 user.Id,
+		"primary_email":                      user.PrimaryEmail,
+		"is_admin":                           user.IsAdmin,
+		"is_delegated_admin":                 user.IsDelegatedAdmin,
+		"agreed_to_terms":                    user.AgreedToTerms,
+		"suspended":                          user.Suspended,
+		"change_password_at_next_login":      user.ChangePasswordAtNextLogin,
+		"ip_allowlist":                       user.IpWhitelisted,
+		"name":                               flattenName(user.Name),
+		"emails":                             flattenInterfaceObjects(user.Emails),
+		"external_ids":                       flattenInterfaceObjects(user.ExternalIds),
+		"relations":                          flattenInterfaceObjects(user.Relations),
+		"etag":                               user.Etag,
+		"aliases":                            user.Aliases,
+		"is_mailbox_setup":                   user.IsMailboxSetup,
+		"customer_id":                        user.CustomerId,
+		"addresses":                          flattenInterfaceObjects(user.Addresses),
+		"organizations":                      flattenInterfaceObjects(user.Organizations),
+		"last_login_time":                    user.LastLoginTime,
+		"phones":                             flattenInterfaceObjects(user.Phones),
+		"suspension_reason":                  user.SuspensionReason,
+		"thumbnail_photo_url":                user.ThumbnailPhotoUrl,
+		"languages":                          flattenInterfaceObjects(user.Languages),
+		"posix_accounts":                     flattenInterfaceObjects(user.PosixAccounts),
+		"creation_time":                      user.CreationTime,
+		"non_editable_aliases":               user.NonEditableAliases,
+		"ssh_public_keys":                    flattenInterfaceObjects(user.SshPublicKeys),
+		"websites":                           flattenInterfaceObjects(user.Websites),
+		"locations":                          flattenInterfaceObjects(user.Locations),
+		"include_in_global_address_list":     user.IncludeInGlobalAddressList,
+		"keywords":                           flattenInterfaceObjects(user.Keywords),
+		"deletion_time":                      user.DeletionTime,
+		"thumbnail_photo_etag":               user.ThumbnailPhotoEtag,
+		"ims":                                flattenInterfaceObjects(user.Ims),
+		"custom_schemas":                     customSchemas,
+		"is_enrolled_in_2_step_verification": user.IsEnrolledIn2Sv,
+		"is_enforced_in_2_step_verification": user.IsEnforcedIn2Sv,
+		"archived":                           user.Archived,
+		"org_unit_path":                      user.OrgUnitPath,
+		"recovery_email":                     user.RecoveryEmail,
+		"recovery_phone":                     user.RecoveryPhone,
+	}
+}
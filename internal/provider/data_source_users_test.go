@@ -0,0 +1,152 @@
+package googleworkspace
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestQuoteUsersQueryValue(t *testing.T) {
+	cases := map[string]string{
+		"/Engineering":         `'/Engineering'`,
+		"/Sales/EMEA Sales":    `'/Sales/EMEA Sales'`,
+		"/Partners/O'Reilly":   `'/Partners/O\'Reilly'`,
+		`/Partners/Back\Slash`: `'/Partners/Back\\Slash'`,
+	}
+
+	for v, expected := range cases {
+		if quoted := quoteUsersQueryValue(v); quoted != expected {
+			t.Errorf("expected %q to be quoted as %s, got %s", v, expected, quoted)
+		}
+	}
+}
+
+func TestAccDataSourceUsers_query(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testUserVals := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"familyName": fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceUsers_query(testUserVals),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.googleworkspace_users.my-users", "users.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.googleworkspace_users.my-users", "users.*",
+						map[string]string{
+							"primary_email":      Nprintf("%{userEmail}-1@%{domainName}", testUserVals),
+							"name.0.family_name": testUserVals["familyName"].(string),
+							"name.0.given_name":  "Michael",
+						}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.googleworkspace_users.my-users", "users.*",
+						map[string]string{
+							"primary_email": Nprintf("%{userEmail}-2@%{domainName}", testUserVals),
+						}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceUsers_orgUnitPath(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testUserVals := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"ouName":     fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceUsers_orgUnitPath(testUserVals),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.googleworkspace_users.my-users", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.googleworkspace_users.my-users", "users.0.primary_email",
+						Nprintf("%{userEmail}@%{domainName}", testUserVals)),
+					resource.TestCheckResourceAttr("data.googleworkspace_users.my-users", "users.0.org_unit_path",
+						Nprintf("/%{ouName}", testUserVals)),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceUsers_query(testUserVals map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_user" "my-new-user-1" {
+  primary_email = "%{userEmail}-1@%{domainName}"
+  password = "%{password}"
+
+  name {
+    family_name = "%{familyName}"
+    given_name = "Michael"
+  }
+}
+
+resource "googleworkspace_user" "my-new-user-2" {
+  primary_email = "%{userEmail}-2@%{domainName}"
+  password = "%{password}"
+
+  name {
+    family_name = "%{familyName}"
+    given_name = "Dwight"
+  }
+}
+
+data "googleworkspace_users" "my-users" {
+  query = "familyName:${googleworkspace_user.my-new-user-1.name[0].family_name}"
+
+  depends_on = [googleworkspace_user.my-new-user-2]
+}
+`, testUserVals)
+}
+
+func testAccDataSourceUsers_orgUnitPath(testUserVals map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_org_unit" "my-org-unit" {
+  name = "%{ouName}"
+  parent_org_unit_path = "/"
+}
+
+resource "googleworkspace_user" "my-new-user" {
+  primary_email = "%{userEmail}@%{domainName}"
+  password = "%{password}"
+  org_unit_path = googleworkspace_org_unit.my-org-unit.org_unit_path
+
+  name {
+    family_name = "Scott"
+    given_name = "Michael"
+  }
+}
+
+data "googleworkspace_users" "my-users" {
+  org_unit_path = googleworkspace_user.my-new-user.org_unit_path
+}
+`, testUserVals)
+}
@@ -0,0 +1,795 @@
+package googleworkspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	fakeChromePolicyPath = "/v1/customers/([^/]+)/"
+
+	// policy values are stored by target, additional target keys and schema
+	fakeChromePolicies = "chromepolicies"
+	// group priority orderings are stored by namespace and additional target keys
+	fakeChromePolicyGroupPriorities = "chromepolicygrouppriorities"
+)
+
+type fakePolicySchema struct {
+	schemaName  string
+	description string
+	// additional target keys, by name, with their descriptions
+	targetKeys map[string]string
+	// the first message is that of the policy's value
+	messages []fakePolicyMessage
+	enums    map[string][]string
+}
+
+type fakePolicyMessage struct {
+	name   string
+	fields []fakePolicyField
+}
+
+type fakePolicyField struct {
+	name        string
+	fieldType   string
+	typeName    string
+	repeated    bool
+	description string
+}
+
+// fakePolicySchemas is the catalog of policy schemas of the fake.
+var fakePolicySchemas = []fakePolicySchema{
+	{
+		schemaName:  "chrome.printers.AllowForUsers",
+		description: "Allows a printer for users in a given organization.",
+		targetKeys:  map[string]string{"printer_id": "Id of printer within organization."},
+		messages: []fakePolicyMessage{
+			{name: "AllowForUsers", fields: []fakePolicyField{
+				{name: "allowForUsers", fieldType: "TYPE_BOOL", description: "Controls whether a printer is allowed for users."},
+			}},
+		},
+	},
+	{
+		schemaName:  "chrome.users.ManagedBookmarksSetting",
+		description: "Managed bookmarks.",
+		messages: []fakePolicyMessage{
+			{name: "ManagedBookmarksSetting", fields: []fakePolicyField{
+				{name: "managedBookmarks", fieldType: "TYPE_MESSAGE", typeName: ".chrome.users.ManagedBookmarks", description: "Managed bookmarks."},
+			}},
+			{name: "ManagedBookmarks", fields: []fakePolicyField{
+				{name: "toplevelName", fieldType: "TYPE_STRING", description: "Top-level folder name."},
+				{name: "bookmarks", fieldType: "TYPE_MESSAGE", typeName: ".chrome.users.BookmarkOrFolder", repeated: true},
+			}},
+			{name: "BookmarkOrFolder", fields: []fakePolicyField{
+				{name: "name", fieldType: "TYPE_STRING"},
+				{name: "url", fieldType: "TYPE_STRING"},
+			}},
+		},
+	},
+	{
+		schemaName:  "chrome.users.MaxConnectionsPerProxy",
+		description: "Maximum number of concurrent connections to the proxy server.",
+		messages: []fakePolicyMessage{
+			{name: "MaxConnectionsPerProxy", fields: []fakePolicyField{
+				{name: "maxConnectionsPerProxy", fieldType: "TYPE_INT64", description: "Maximum number of concurrent connections to the proxy server."},
+			}},
+		},
+	},
+	{
+		schemaName:  "chrome.users.OnlineRevocationChecks",
+		description: "Online revocation checks.",
+		messages: []fakePolicyMessage{
+			{name: "OnlineRevocationChecks", fields: []fakePolicyField{
+				{name: "enableOnlineRevocationChecks", fieldType: "TYPE_BOOL", description: "Perform online OCSP/CRL checks."},
+			}},
+		},
+	},
+	{
+		schemaName:  "chrome.users.ProxySettings",
+		description: "Proxy settings.",
+		messages: []fakePolicyMessage{
+			{name: "ProxySettings", fields: []fakePolicyField{
+				{name: "proxyMode", fieldType: "TYPE_ENUM", typeName: ".chrome.users.ProxyModeEnum", description: "Proxy mode."},
+				{name: "proxyServer", fieldType: "TYPE_STRING", description: "Proxy server URL."},
+				{name: "proxyPacUrl", fieldType: "TYPE_STRING", description: "Proxy server auto configuration file URL."},
+			}},
+		},
+		enums: map[string][]string{
+			"ProxyModeEnum": {"PROXY_MODE_ENUM_DIRECT", "PROXY_MODE_ENUM_AUTO_DETECT", "PROXY_MODE_ENUM_PAC_SCRIPT",
+				"PROXY_MODE_ENUM_FIXED_SERVERS", "PROXY_MODE_ENUM_SYSTEM"},
+		},
+	},
+	{
+		schemaName:  "chrome.users.RestrictSigninToPattern",
+		description: "Restrict sign-in to a list of users.",
+		messages: []fakePolicyMessage{
+			{name: "RestrictSigninToPattern", fields: []fakePolicyField{
+				{name: "restrictSigninToPattern", fieldType: "TYPE_STRING", description: "Restrict which accounts can be used as browser primary accounts."},
+			}},
+		},
+	},
+	{
+		schemaName:  "chrome.users.apps.InstallType",
+		description: "Specifies the manner in which the app is to be installed.",
+		targetKeys:  map[string]string{"app_id": "App id of the app."},
+		messages: []fakePolicyMessage{
+			{name: "InstallType", fields: []fakePolicyField{
+				{name: "appInstallType", fieldType: "TYPE_ENUM", typeName: ".chrome.users.apps.AppInstallTypeEnum", description: "Installation type."},
+			}},
+		},
+		enums: map[string][]string{
+			"AppInstallTypeEnum": {"APP_INSTALL_TYPE_ENUM_UNSPECIFIED", "APP_INSTALL_TYPE_ENUM_NOT_INSTALLED",
+				"APP_INSTALL_TYPE_ENUM_FORCED", "APP_INSTALL_TYPE_ENUM_ALLOWED", "APP_INSTALL_TYPE_ENUM_BLOCKED"},
+		},
+	},
+}
+
+func (f *fakeWorkspace) registerChromePolicyRoutes() {
+	f.route(http.MethodGet, fakeChromePolicyPath+"policySchemas", f.listPolicySchemas)
+	f.route(http.MethodGet, fakeChromePolicyPath+"policySchemas/(.+)", f.getPolicySchema)
+	f.route(http.MethodPost, fakeChromePolicyPath+"policies:resolve", f.resolvePolicies)
+	f.route(http.MethodPost, fakeChromePolicyPath+"policies/orgunits:batchModify", f.batchModifyPolicies("orgunits/"))
+	f.route(http.MethodPost, fakeChromePolicyPath+"policies/orgunits:batchInherit", f.batchRemovePolicies("orgunits/"))
+	f.route(http.MethodPost, fakeChromePolicyPath+"policies/groups:batchModify", f.batchModifyPolicies("groups/"))
+	f.route(http.MethodPost, fakeChromePolicyPath+"policies/groups:batchDelete", f.batchRemovePolicies("groups/"))
+	f.route(http.MethodPost, fakeChromePolicyPath+"policies/groups:listGroupPriorityOrdering", f.listGroupPriorityOrdering)
+	f.route(http.MethodPost, fakeChromePolicyPath+"policies/groups:updateGroupPriorityOrdering", f.updateGroupPriorityOrdering)
+}
+
+// seedChromePolicy sets the policies every tenant has.
+func (f *fakeWorkspace) seedChromePolicy() {
+	f.seed(fakeChromePolicies, fakePolicyKey(fakeOrgUnitTarget(fakeRootOrgUnitId), nil, "chrome.users.OnlineRevocationChecks"), map[string]interface{}{
+		"policySchema": "chrome.users.OnlineRevocationChecks",
+		"value": map[string]interface{}{
+			"enableOnlineRevocationChecks": false,
+		},
+	})
+}
+
+// fakeOrgUnitTarget returns the policy target of the org unit with the id.
+func fakeOrgUnitTarget(orgUnitId string) string {
+	return "orgunits/" + strings.TrimPrefix(orgUnitId, "id:")
+}
+
+func fakePolicyKey(target string, additionalTargetKeys map[string]string, schemaName string) string {
+	var keys []string
+	for k, v := range additionalTargetKeys {
+		keys = append(keys, k+"="+v)
+	}
+	sort.Strings(keys)
+
+	return target + "|" + strings.Join(keys, ",") + "|" + schemaName
+}
+
+// removeChromePolicies removes the policy values of a target that's deleted.
+func (f *fakeWorkspace) removeChromePolicies(target string) {
+	for _, key := range f.keys(fakeChromePolicies) {
+		if strings.HasPrefix(key, target+"|") {
+			f.remove(fakeChromePolicies, key)
+		}
+	}
+}
+
+func findFakePolicySchema(schemaName string) (fakePolicySchema, bool) {
+	for _, s := range fakePolicySchemas {
+		if s.schemaName == schemaName {
+			return s, true
+		}
+	}
+
+	return fakePolicySchema{}, false
+}
+
+// matchFakePolicySchemas returns the schemas matched by a filter, which is
+// either a schema name or a namespace followed by `.*`.
+func matchFakePolicySchemas(filter string) []fakePolicySchema {
+	var schemas []fakePolicySchema
+	for _, s := range fakePolicySchemas {
+		if s.schemaName == filter || (strings.HasSuffix(filter, ".*") && strings.HasPrefix(s.schemaName, strings.TrimSuffix(filter, "*"))) {
+			schemas = append(schemas, s)
+		}
+	}
+
+	return schemas
+}
+
+func (s fakePolicySchema) namespace() string {
+	return s.schemaName[:strings.LastIndex(s.schemaName, ".")]
+}
+
+func (s fakePolicySchema) message(typeName string) (fakePolicyMessage, bool) {
+	for _, m := range s.messages {
+		if typeName == "."+s.namespace()+"."+m.name || typeName == m.name {
+			return m, true
+		}
+	}
+
+	return fakePolicyMessage{}, false
+}
+
+func (m fakePolicyMessage) field(name string) (fakePolicyField, bool) {
+	for _, field := range m.fields {
+		if field.name == name {
+			return field, true
+		}
+	}
+
+	return fakePolicyField{}, false
+}
+
+// resource returns the schema as the API does.
+func (s fakePolicySchema) resource(customer string) map[string]interface{} {
+	var messageTypes, fieldDescriptions []interface{}
+	for _, m := range s.messages {
+		var fields []interface{}
+		for i, field := range m.fields {
+			label := "LABEL_OPTIONAL"
+			if field.repeated {
+				label = "LABEL_REPEATED"
+			}
+
+			descriptor := map[string]interface{}{
+				"name":   field.name,
+				"number": i + 1,
+				"label":  label,
+				"type":   field.fieldType,
+			}
+			if field.typeName != "" {
+				descriptor["typeName"] = field.typeName
+			}
+			fields = append(fields, descriptor)
+
+			if field.description != "" {
+				fieldDescriptions = append(fieldDescriptions, map[string]interface{}{
+					"field":       field.name,
+					"description": field.description,
+				})
+			}
+		}
+
+		messageTypes = append(messageTypes, map[string]interface{}{
+			"name":  m.name,
+			"field": fields,
+		})
+	}
+
+	var enumTypes []interface{}
+	for _, name := range sortedFakePrivilegeNames(s.enums) {
+		var values []interface{}
+		for i, value := range s.enums[name] {
+			values = append(values, map[string]interface{}{
+				"name":   value,
+				"number": i,
+			})
+		}
+
+		enumTypes = append(enumTypes, map[string]interface{}{
+			"name":  name,
+			"value": values,
+		})
+	}
+
+	var targetKeyNames []interface{}
+	for _, key := range sortedFakeTargetKeys(s.targetKeys) {
+		targetKeyNames = append(targetKeyNames, map[string]interface{}{
+			"key":            key,
+			"keyDescription": s.targetKeys[key],
+		})
+	}
+
+	resource := map[string]interface{}{
+		"name":              fmt.Sprintf("customers/%s/policySchemas/%s", customer, s.schemaName),
+		"schemaName":        s.schemaName,
+		"policyDescription": s.description,
+		"supportUri":        "https://support.google.com/chrome/a?p=" + strings.ToLower(s.messages[0].name),
+		"definition": map[string]interface{}{
+			"name":        strings.ReplaceAll(s.schemaName, ".", "/") + ".proto",
+			"package":     s.namespace(),
+			"syntax":      "proto2",
+			"messageType": messageTypes,
+			"enumType":    enumTypes,
+		},
+		"fieldDescriptions": fieldDescriptions,
+	}
+	if targetKeyNames != nil {
+		resource["additionalTargetKeyNames"] = targetKeyNames
+	}
+
+	return resource
+}
+
+func sortedFakeTargetKeys(keys map[string]string) []string {
+	var names []string
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// checkValue reports why a value of the message is invalid, if it is.
+func (s fakePolicySchema) checkValue(m fakePolicyMessage, value map[string]interface{}) error {
+	for name, v := range value {
+		field, ok := m.field(name)
+		if !ok {
+			return fmt.Errorf("Invalid JSON payload received. Unknown name %q at 'requests[0].policy_value.value': Cannot find field.", name)
+		}
+
+		values := []interface{}{v}
+		if field.repeated {
+			items, ok := v.([]interface{})
+			if !ok {
+				return fmt.Errorf("Invalid value at %q, expected a list", name)
+			}
+			values = items
+		}
+
+		for _, item := range values {
+			if err := s.checkFieldValue(field, item); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s fakePolicySchema) checkFieldValue(field fakePolicyField, v interface{}) error {
+	var valid bool
+	switch field.fieldType {
+	case "TYPE_BOOL":
+		_, valid = v.(bool)
+	case "TYPE_INT64":
+		// integers may be sent as numbers or strings
+		switch n := v.(type) {
+		case float64:
+			valid = n == math.Trunc(n)
+		case string:
+			_, err := strconv.ParseInt(n, 10, 64)
+			valid = err == nil
+		}
+	case "TYPE_ENUM":
+		var name string
+		name, valid = v.(string)
+		if valid {
+			enum := field.typeName[strings.LastIndex(field.typeName, ".")+1:]
+			valid = false
+			for _, value := range s.enums[enum] {
+				valid = valid || value == name
+			}
+		}
+	case "TYPE_MESSAGE":
+		var value map[string]interface{}
+		value, valid = v.(map[string]interface{})
+		if valid {
+			m, ok := s.message(field.typeName)
+			if !ok {
+				return fmt.Errorf("Unknown message type %q", field.typeName)
+			}
+			return s.checkValue(m, value)
+		}
+	default:
+		_, valid = v.(string)
+	}
+
+	if !valid {
+		return fmt.Errorf("Invalid value at %q (%s), %v", field.name, field.fieldType, v)
+	}
+
+	return nil
+}
+
+// formatValue returns a value of the message as the API does, with 64 bit
+// integers as strings.
+func (s fakePolicySchema) formatValue(m fakePolicyMessage, value map[string]interface{}) map[string]interface{} {
+	formatted := map[string]interface{}{}
+	for name, v := range value {
+		field, _ := m.field(name)
+
+		format := func(v interface{}) interface{} {
+			switch field.fieldType {
+			case "TYPE_INT64":
+				if n, ok := v.(float64); ok {
+					return strconv.FormatInt(int64(n), 10)
+				}
+			case "TYPE_MESSAGE":
+				nested, _ := s.message(field.typeName)
+				if obj, ok := v.(map[string]interface{}); ok {
+					return s.formatValue(nested, obj)
+				}
+			}
+
+			return v
+		}
+
+		if items, ok := v.([]interface{}); ok {
+			var values []interface{}
+			for _, item := range items {
+				values = append(values, format(item))
+			}
+			formatted[name] = values
+		} else {
+			formatted[name] = format(v)
+		}
+	}
+
+	return formatted
+}
+
+type fakePolicyTargetKey struct {
+	TargetResource       string            `json:"targetResource"`
+	AdditionalTargetKeys map[string]string `json:"additionalTargetKeys,omitempty"`
+}
+
+// checkTarget finds the org unit or group a policy is targeted at, and checks
+// its additional target keys are those of the schema. It returns the targets
+// the value of the policy is resolved from, nearest first, which are the org
+// unit and its parents, or the group.
+func (f *fakeWorkspace) checkTarget(key fakePolicyTargetKey, s fakePolicySchema) ([]string, error) {
+	var targets []string
+	switch {
+	case strings.HasPrefix(key.TargetResource, "orgunits/"):
+		orgUnitId := "id:" + strings.TrimPrefix(key.TargetResource, "orgunits/")
+		if _, ok := f.get(fakeOrgUnits, orgUnitId); !ok {
+			return nil, fmt.Errorf("Requested entity was not found.")
+		}
+
+		for orgUnitId != "" {
+			targets = append(targets, fakeOrgUnitTarget(orgUnitId))
+
+			parent, _ := f.get(fakeOrgUnits, orgUnitId)
+			orgUnitId = fakeString(fakeFields(parent), "parentOrgUnitId")
+		}
+	case strings.HasPrefix(key.TargetResource, "groups/"):
+		if _, ok := f.get(fakeGroups, strings.TrimPrefix(key.TargetResource, "groups/")); !ok {
+			return nil, fmt.Errorf("Requested entity was not found.")
+		}
+
+		targets = append(targets, key.TargetResource)
+	default:
+		return nil, fmt.Errorf("Invalid target resource: %s", key.TargetResource)
+	}
+
+	for k := range key.AdditionalTargetKeys {
+		if _, ok := s.targetKeys[k]; !ok {
+			return nil, fmt.Errorf("Unknown additional target key %q for policy schema %s", k, s.schemaName)
+		}
+	}
+	for k := range s.targetKeys {
+		if key.AdditionalTargetKeys[k] == "" {
+			return nil, fmt.Errorf("Missing additional target key %q for policy schema %s", k, s.schemaName)
+		}
+	}
+
+	return targets, nil
+}
+
+func (f *fakeWorkspace) listPolicySchemas(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	filter := strings.TrimSuffix(r.URL.Query().Get("filter"), "*")
+
+	var items []json.RawMessage
+	for _, s := range fakePolicySchemas {
+		if strings.HasPrefix(s.schemaName, filter) {
+			items = append(items, mustMarshalFake(s.resource(params[0])))
+		}
+	}
+
+	page, nextPageToken := paginateFake(r, items)
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"policySchemas": page,
+		"nextPageToken": nextPageToken,
+	})
+}
+
+func (f *fakeWorkspace) getPolicySchema(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	s, ok := findFakePolicySchema(params[1])
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Requested entity was not found.", "notFound")
+		return
+	}
+
+	writeFakeJSON(w, http.StatusOK, s.resource(params[0]))
+}
+
+// resolvePolicies resolves the value of each matching policy at the target,
+// which is the value set at the nearest org unit up from it, or on the group.
+func (f *fakeWorkspace) resolvePolicies(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	var req struct {
+		PolicySchemaFilter string              `json:"policySchemaFilter"`
+		PolicyTargetKey    fakePolicyTargetKey `json:"policyTargetKey"`
+	}
+	if !decodeFakeRequest(w, r, &req) {
+		return
+	}
+
+	schemas := matchFakePolicySchemas(req.PolicySchemaFilter)
+	if len(schemas) == 0 {
+		writeFakeBadRequest(w, "Invalid policy schema filter: "+req.PolicySchemaFilter)
+		return
+	}
+
+	resolved := []interface{}{}
+	for _, s := range schemas {
+		targets, err := f.checkTarget(req.PolicyTargetKey, s)
+		if err != nil {
+			// a namespace only resolves the schemas that apply to the target
+			if len(schemas) > 1 {
+				continue
+			}
+			writeFakeBadRequest(w, err.Error())
+			return
+		}
+
+		for _, target := range targets {
+			if obj, ok := f.get(fakeChromePolicies, fakePolicyKey(target, req.PolicyTargetKey.AdditionalTargetKeys, s.schemaName)); ok {
+				policy := fakeFields(obj)
+				value, _ := policy["value"].(map[string]interface{})
+
+				resolved = append(resolved, map[string]interface{}{
+					"targetKey": req.PolicyTargetKey,
+					"sourceKey": fakePolicyTargetKey{
+						TargetResource:       target,
+						AdditionalTargetKeys: req.PolicyTargetKey.AdditionalTargetKeys,
+					},
+					"value": map[string]interface{}{
+						"policySchema": s.schemaName,
+						"value":        s.formatValue(s.messages[0], value),
+					},
+				})
+				break
+			}
+		}
+	}
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"resolvedPolicies": resolved,
+	})
+}
+
+// batchModifyPolicies sets the policies of the org units or groups, as named
+// by the prefix of their targets.
+func (f *fakeWorkspace) batchModifyPolicies(targetPrefix string) func(w http.ResponseWriter, r *http.Request, params []string) {
+	return func(w http.ResponseWriter, r *http.Request, params []string) {
+		f.modifyPolicies(w, r, params, targetPrefix)
+	}
+}
+
+func (f *fakeWorkspace) modifyPolicies(w http.ResponseWriter, r *http.Request, params []string, targetPrefix string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	var req struct {
+		Requests []struct {
+			PolicyTargetKey fakePolicyTargetKey `json:"policyTargetKey"`
+			PolicyValue     struct {
+				PolicySchema string                 `json:"policySchema"`
+				Value        map[string]interface{} `json:"value"`
+			} `json:"policyValue"`
+			UpdateMask string `json:"updateMask"`
+		} `json:"requests"`
+	}
+	if !decodeFakeRequest(w, r, &req) {
+		return
+	}
+
+	// the requests are validated before any of them are applied
+	for _, modify := range req.Requests {
+		s, ok := findFakePolicySchema(modify.PolicyValue.PolicySchema)
+		if !ok {
+			writeFakeBadRequest(w, "Invalid policy schema: "+modify.PolicyValue.PolicySchema)
+			return
+		}
+		if !strings.HasPrefix(modify.PolicyTargetKey.TargetResource, targetPrefix) {
+			writeFakeBadRequest(w, "Invalid target resource: "+modify.PolicyTargetKey.TargetResource)
+			return
+		}
+		if _, err := f.checkTarget(modify.PolicyTargetKey, s); err != nil {
+			writeFakeBadRequest(w, err.Error())
+			return
+		}
+		if err := s.checkValue(s.messages[0], modify.PolicyValue.Value); err != nil {
+			writeFakeBadRequest(w, err.Error())
+			return
+		}
+		for _, name := range strings.Split(modify.UpdateMask, ",") {
+			if _, ok := s.messages[0].field(name); !ok {
+				writeFakeBadRequest(w, fmt.Sprintf("Invalid update mask field: %q", name))
+				return
+			}
+		}
+	}
+
+	for _, modify := range req.Requests {
+		key := fakePolicyKey(modify.PolicyTargetKey.TargetResource, modify.PolicyTargetKey.AdditionalTargetKeys, modify.PolicyValue.PolicySchema)
+
+		value := map[string]interface{}{}
+		if obj, ok := f.get(fakeChromePolicies, key); ok {
+			value, _ = fakeFields(obj)["value"].(map[string]interface{})
+		}
+		for _, name := range strings.Split(modify.UpdateMask, ",") {
+			if v, ok := modify.PolicyValue.Value[name]; ok {
+				value[name] = v
+			} else {
+				delete(value, name)
+			}
+		}
+
+		f.seed(fakeChromePolicies, key, map[string]interface{}{
+			"policySchema": modify.PolicyValue.PolicySchema,
+			"value":        value,
+		})
+	}
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+// batchRemovePolicies removes the policies of the org units, which then
+// inherit them, or of the groups, as named by the prefix of their targets.
+func (f *fakeWorkspace) batchRemovePolicies(targetPrefix string) func(w http.ResponseWriter, r *http.Request, params []string) {
+	return func(w http.ResponseWriter, r *http.Request, params []string) {
+		f.removePolicies(w, r, params, targetPrefix)
+	}
+}
+
+func (f *fakeWorkspace) removePolicies(w http.ResponseWriter, r *http.Request, params []string, targetPrefix string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	var req struct {
+		Requests []struct {
+			PolicyTargetKey fakePolicyTargetKey `json:"policyTargetKey"`
+			PolicySchema    string              `json:"policySchema"`
+		} `json:"requests"`
+	}
+	if !decodeFakeRequest(w, r, &req) {
+		return
+	}
+
+	for _, inherit := range req.Requests {
+		if !strings.HasPrefix(inherit.PolicyTargetKey.TargetResource, targetPrefix) {
+			writeFakeBadRequest(w, "Invalid target resource: "+inherit.PolicyTargetKey.TargetResource)
+			return
+		}
+
+		schemas := matchFakePolicySchemas(inherit.PolicySchema)
+		if len(schemas) == 0 {
+			writeFakeBadRequest(w, "Invalid policy schema: "+inherit.PolicySchema)
+			return
+		}
+
+		for _, s := range schemas {
+			if _, err := f.checkTarget(inherit.PolicyTargetKey, s); err != nil {
+				if len(schemas) > 1 {
+					continue
+				}
+				writeFakeBadRequest(w, err.Error())
+				return
+			}
+
+			f.remove(fakeChromePolicies, fakePolicyKey(inherit.PolicyTargetKey.TargetResource, inherit.PolicyTargetKey.AdditionalTargetKeys, s.schemaName))
+		}
+	}
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+type fakeGroupPriorityOrdering struct {
+	PolicyTargetKey fakePolicyTargetKey `json:"policyTargetKey"`
+	PolicyNamespace string              `json:"policyNamespace"`
+	PolicySchema    string              `json:"policySchema,omitempty"`
+	GroupIds        []string            `json:"groupIds"`
+}
+
+// groupPriorityOrdering returns the groups that have policies of the namespace
+// for the app, in the order that's set, followed by those that aren't in it.
+func (f *fakeWorkspace) groupPriorityOrdering(req fakeGroupPriorityOrdering) ([]string, error) {
+	schemas := matchFakePolicySchemas(req.PolicyNamespace + ".*")
+	if len(schemas) == 0 || len(req.PolicyTargetKey.AdditionalTargetKeys) == 0 {
+		return nil, fmt.Errorf("Invalid policy namespace or target key: %s", req.PolicyNamespace)
+	}
+
+	hasPolicies := map[string]bool{}
+	for _, key := range f.keys(fakeChromePolicies) {
+		if !strings.HasPrefix(key, "groups/") {
+			continue
+		}
+
+		target := key[:strings.Index(key, "|")]
+		for _, s := range schemas {
+			if key == fakePolicyKey(target, req.PolicyTargetKey.AdditionalTargetKeys, s.schemaName) {
+				hasPolicies[strings.TrimPrefix(target, "groups/")] = true
+			}
+		}
+	}
+
+	var groupIds []string
+	if obj, ok := f.get(fakeChromePolicyGroupPriorities, fakePolicyKey("", req.PolicyTargetKey.AdditionalTargetKeys, req.PolicyNamespace)); ok {
+		for _, id := range fakeStrings(fakeFields(obj), "groupIds") {
+			if hasPolicies[id] {
+				groupIds = append(groupIds, id)
+				delete(hasPolicies, id)
+			}
+		}
+	}
+
+	var rest []string
+	for id := range hasPolicies {
+		rest = append(rest, id)
+	}
+	sort.Strings(rest)
+
+	return append(groupIds, rest...), nil
+}
+
+func (f *fakeWorkspace) listGroupPriorityOrdering(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	var req fakeGroupPriorityOrdering
+	if !decodeFakeRequest(w, r, &req) {
+		return
+	}
+
+	groupIds, err := f.groupPriorityOrdering(req)
+	if err != nil {
+		writeFakeBadRequest(w, err.Error())
+		return
+	}
+
+	req.GroupIds = groupIds
+	writeFakeJSON(w, http.StatusOK, req)
+}
+
+// updateGroupPriorityOrdering sets the order of the groups, which must be
+// every group that has policies for the app.
+func (f *fakeWorkspace) updateGroupPriorityOrdering(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	var req fakeGroupPriorityOrdering
+	if !decodeFakeRequest(w, r, &req) {
+		return
+	}
+
+	current, err := f.groupPriorityOrdering(req)
+	if err != nil {
+		writeFakeBadRequest(w, err.Error())
+		return
+	}
+
+	sorted := append([]string{}, req.GroupIds...)
+	sort.Strings(sorted)
+	sort.Strings(current)
+	if strings.Join(sorted, ",") != strings.Join(current, ",") {
+		writeFakeBadRequest(w, fmt.Sprintf("Group ids %v must be those of the groups with policies for the app, %v", req.GroupIds, current))
+		return
+	}
+
+	f.seed(fakeChromePolicyGroupPriorities, fakePolicyKey("", req.PolicyTargetKey.AdditionalTargetKeys, req.PolicyNamespace), map[string]interface{}{
+		"groupIds": req.GroupIds,
+	})
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{})
+}
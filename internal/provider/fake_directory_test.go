@@ -0,0 +1,1847 @@
+package googleworkspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	fakeDirectoryPath = "/admin/directory/v1/"
+
+	fakeRootOrgUnitId = "id:03ph8a2z0fakeroot"
+)
+
+// The Directory API collections of the fake. Members are stored per group.
+const (
+	fakeUsers           = "users"
+	fakeGroups          = "groups"
+	fakeMembersPrefix   = "members/"
+	fakeOrgUnits        = "orgunits"
+	fakeDomains         = "domains"
+	fakeDomainAliases   = "domainaliases"
+	fakeRoles           = "roles"
+	fakeRoleAssignments = "roleassignments"
+	fakeSchemas         = "schemas"
+	fakeCustomers       = "customers"
+)
+
+func (f *fakeWorkspace) registerDirectoryRoutes() {
+	const v1 = fakeDirectoryPath
+	const customer = v1 + "customer/([^/]+)/"
+
+	f.route(http.MethodPost, v1+"users", f.insertUser)
+	f.route(http.MethodGet, v1+"users", f.listUsers)
+	f.route(http.MethodGet, v1+"users/([^/]+)", f.getUser)
+	f.route(http.MethodPut, v1+"users/([^/]+)", f.updateUser)
+	f.route(http.MethodPatch, v1+"users/([^/]+)", f.updateUser)
+	f.route(http.MethodDelete, v1+"users/([^/]+)", f.deleteUser)
+	f.route(http.MethodPost, v1+"users/([^/]+)/makeAdmin", f.makeAdmin)
+	f.route(http.MethodPost, v1+"users/([^/]+)/aliases", f.insertAlias(fakeUsers))
+	f.route(http.MethodGet, v1+"users/([^/]+)/aliases", f.listAliases(fakeUsers))
+	f.route(http.MethodDelete, v1+"users/([^/]+)/aliases/([^/]+)", f.deleteAlias(fakeUsers))
+
+	f.route(http.MethodPost, v1+"groups", f.insertGroup)
+	f.route(http.MethodGet, v1+"groups", f.listGroups)
+	f.route(http.MethodGet, v1+"groups/([^/]+)", f.getGroup)
+	f.route(http.MethodPut, v1+"groups/([^/]+)", f.updateGroup)
+	f.route(http.MethodPatch, v1+"groups/([^/]+)", f.updateGroup)
+	f.route(http.MethodDelete, v1+"groups/([^/]+)", f.deleteGroup)
+	f.route(http.MethodPost, v1+"groups/([^/]+)/aliases", f.insertAlias(fakeGroups))
+	f.route(http.MethodGet, v1+"groups/([^/]+)/aliases", f.listAliases(fakeGroups))
+	f.route(http.MethodDelete, v1+"groups/([^/]+)/aliases/([^/]+)", f.deleteAlias(fakeGroups))
+
+	f.route(http.MethodPost, v1+"groups/([^/]+)/members", f.insertMember)
+	f.route(http.MethodGet, v1+"groups/([^/]+)/members", f.listMembers)
+	f.route(http.MethodGet, v1+"groups/([^/]+)/members/([^/]+)", f.getMember)
+	f.route(http.MethodPut, v1+"groups/([^/]+)/members/([^/]+)", f.updateMember)
+	f.route(http.MethodPatch, v1+"groups/([^/]+)/members/([^/]+)", f.updateMember)
+	f.route(http.MethodDelete, v1+"groups/([^/]+)/members/([^/]+)", f.deleteMember)
+	f.route(http.MethodGet, v1+"groups/([^/]+)/hasMember/([^/]+)", f.hasMember)
+
+	f.route(http.MethodPost, customer+"orgunits", f.insertOrgUnit)
+	f.route(http.MethodGet, customer+"orgunits", f.listOrgUnits)
+	f.route(http.MethodGet, customer+"orgunits/(.+)", f.getOrgUnit)
+	f.route(http.MethodPut, customer+"orgunits/(.+)", f.updateOrgUnit)
+	f.route(http.MethodPatch, customer+"orgunits/(.+)", f.updateOrgUnit)
+	f.route(http.MethodDelete, customer+"orgunits/(.+)", f.deleteOrgUnit)
+
+	f.route(http.MethodPost, customer+"domains", f.insertDomain)
+	f.route(http.MethodGet, customer+"domains", f.listDomains)
+	f.route(http.MethodGet, customer+"domains/([^/]+)", f.getDomain)
+	f.route(http.MethodDelete, customer+"domains/([^/]+)", f.deleteDomain)
+
+	f.route(http.MethodPost, customer+"domainaliases", f.insertDomainAlias)
+	f.route(http.MethodGet, customer+"domainaliases", f.listDomainAliases)
+	f.route(http.MethodGet, customer+"domainaliases/([^/]+)", f.getDomainAlias)
+	f.route(http.MethodDelete, customer+"domainaliases/([^/]+)", f.deleteDomainAlias)
+
+	f.route(http.MethodGet, customer+"roles/ALL/privileges", f.listPrivileges)
+	f.route(http.MethodPost, customer+"roles", f.insertRole)
+	f.route(http.MethodGet, customer+"roles", f.listRoles)
+	f.route(http.MethodGet, customer+"roles/([^/]+)", f.getRole)
+	f.route(http.MethodPut, customer+"roles/([^/]+)", f.updateRole)
+	f.route(http.MethodPatch, customer+"roles/([^/]+)", f.updateRole)
+	f.route(http.MethodDelete, customer+"roles/([^/]+)", f.deleteRole)
+
+	f.route(http.MethodPost, customer+"roleassignments", f.insertRoleAssignment)
+	f.route(http.MethodGet, customer+"roleassignments", f.listRoleAssignments)
+	f.route(http.MethodGet, customer+"roleassignments/([^/]+)", f.getRoleAssignment)
+	f.route(http.MethodDelete, customer+"roleassignments/([^/]+)", f.deleteRoleAssignment)
+
+	f.route(http.MethodPost, customer+"schemas", f.insertSchema)
+	f.route(http.MethodGet, customer+"schemas", f.listSchemas)
+	f.route(http.MethodGet, customer+"schemas/([^/]+)", f.getSchema)
+	f.route(http.MethodPut, customer+"schemas/([^/]+)", f.updateSchema)
+	f.route(http.MethodPatch, customer+"schemas/([^/]+)", f.updateSchema)
+	f.route(http.MethodDelete, customer+"schemas/([^/]+)", f.deleteSchema)
+
+	f.route(http.MethodGet, v1+"customers/([^/]+)", f.getCustomer)
+}
+
+// seedDirectory creates what every tenant has: the customer, its primary domain
+// and root org unit, the impersonated admin, and the system roles.
+func (f *fakeWorkspace) seedDirectory() {
+	f.seed(fakeCustomers, fakeCustomerId, map[string]interface{}{
+		"kind":                 "admin#directory#customer",
+		"id":                   fakeCustomerId,
+		"customerDomain":       fakeDomain,
+		"alternateEmail":       "alternate@example.net",
+		"language":             "en",
+		"customerCreationTime": "2021-01-01T00:00:00.000Z",
+	})
+
+	f.seed(fakeDomains, fakeDomain, map[string]interface{}{
+		"kind":         "admin#directory#domain",
+		"domainName":   fakeDomain,
+		"isPrimary":    true,
+		"verified":     true,
+		"creationTime": "1609459200000",
+	})
+
+	f.seed(fakeOrgUnits, fakeRootOrgUnitId, map[string]interface{}{
+		"kind":        "admin#directory#orgUnit",
+		"name":        fakeDomain,
+		"orgUnitPath": "/",
+		"orgUnitId":   fakeRootOrgUnitId,
+	})
+
+	admin := map[string]interface{}{
+		"primaryEmail": fakeAdminEmail,
+		"name": map[string]interface{}{
+			"givenName":  "Fake",
+			"familyName": "Admin",
+		},
+		"isAdmin": true,
+	}
+	f.defaultUser(admin)
+	f.seed(fakeUsers, fakeString(admin, "id"), admin)
+
+	for _, role := range fakeSystemRoles() {
+		role["kind"] = "admin#directory#role"
+		role["roleId"] = strconv.FormatInt(f.newId()+fakeRoleIdOffset, 10)
+		role["isSystemRole"] = true
+		f.seed(fakeRoles, fakeString(role, "roleId"), role)
+	}
+}
+
+func (f *fakeWorkspace) checkCustomer(w http.ResponseWriter, customer string) bool {
+	if customer != "my_customer" && customer != fakeCustomerId {
+		writeFakeBadRequest(w, "Bad Request")
+		return false
+	}
+
+	return true
+}
+
+// isCustomerDomain reports whether the email address is in one of the domains
+// or domain aliases of the customer.
+func (f *fakeWorkspace) isCustomerDomain(email string) bool {
+	domain := strings.ToLower(email[strings.LastIndex(email, "@")+1:])
+
+	_, isDomain := f.get(fakeDomains, domain)
+	_, isAlias := f.get(fakeDomainAliases, domain)
+
+	return isDomain || isAlias
+}
+
+// emailInUse reports whether a user or group already has the email address, as
+// its primary address or an alias.
+func (f *fakeWorkspace) emailInUse(email string) bool {
+	_, _, isUser := f.findByEmailOrId(fakeUsers, email)
+	_, _, isGroup := f.findByEmailOrId(fakeGroups, email)
+
+	return isUser || isGroup
+}
+
+// findByEmailOrId finds the user or group whose id, primary address or alias is
+// the key.
+func (f *fakeWorkspace) findByEmailOrId(collection, key string) (string, *fakeObject, bool) {
+	return f.find(collection, func(obj *fakeObject) bool {
+		fields := fakeFields(obj)
+		if fields["id"] == key || strings.EqualFold(fakeString(fields, fakePrimaryEmailField(collection)), key) {
+			return true
+		}
+
+		for _, alias := range fakeStrings(fields, "aliases") {
+			if strings.EqualFold(alias, key) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+func fakePrimaryEmailField(collection string) string {
+	if collection == fakeUsers {
+		return "primaryEmail"
+	}
+
+	return "email"
+}
+
+func (f *fakeWorkspace) defaultUser(user map[string]interface{}) {
+	fakeDefaults(user, map[string]interface{}{
+		"orgUnitPath":                "/",
+		"includeInGlobalAddressList": true,
+		"isMailboxSetup":             true,
+		"agreedToTerms":              true,
+	})
+
+	user["kind"] = "admin#directory#user"
+	user["id"] = fmt.Sprintf("1%020d", f.newId())
+	user["customerId"] = fakeCustomerId
+	user["creationTime"] = time.Now().UTC().Format(time.RFC3339)
+	user["lastLoginTime"] = "1970-01-01T00:00:00.000Z"
+
+	f.normalizeUser(user)
+}
+
+// normalizeUser sets the fields derived from others. Passwords are never
+// returned.
+func (f *fakeWorkspace) normalizeUser(user map[string]interface{}) {
+	delete(user, "password")
+	delete(user, "hashFunction")
+
+	if name, ok := user["name"].(map[string]interface{}); ok {
+		name["fullName"] = strings.TrimSpace(fmt.Sprintf("%s %s", name["givenName"], name["familyName"]))
+	}
+
+	primaryEmail := fakeString(user, "primaryEmail")
+	aliases := fakeStrings(user, "aliases")
+
+	// the primary address, aliases and their test addresses are always listed
+	generated := []string{primaryEmail}
+	for _, email := range append([]string{primaryEmail}, aliases...) {
+		if f.isPrimaryDomain(email) {
+			generated = append(generated, fakeTestEmail(email))
+		}
+	}
+	generated = append(generated, aliases...)
+
+	emails := []interface{}{}
+	if configured, ok := user["emails"].([]interface{}); ok {
+		for _, email := range configured {
+			if address, ok := email.(map[string]interface{})["address"].(string); ok && stringInSlice(generated, address) {
+				continue
+			}
+			emails = append(emails, email)
+		}
+	}
+
+	for i, address := range generated {
+		email := map[string]interface{}{"address": address}
+		if i == 0 {
+			email["primary"] = true
+		}
+		emails = append(emails, email)
+	}
+
+	user["emails"] = emails
+}
+
+func (f *fakeWorkspace) isPrimaryDomain(email string) bool {
+	return strings.EqualFold(email[strings.LastIndex(email, "@")+1:], fakeDomain)
+}
+
+func fakeTestEmail(email string) string {
+	return email + ".test-google-a.com"
+}
+
+func (f *fakeWorkspace) insertUser(w http.ResponseWriter, r *http.Request, params []string) {
+	user := map[string]interface{}{}
+	if !decodeFakeRequest(w, r, &user) {
+		return
+	}
+
+	primaryEmail := fakeString(user, "primaryEmail")
+	name, _ := user["name"].(map[string]interface{})
+	switch {
+	case primaryEmail == "":
+		writeFakeBadRequest(w, "Invalid Input: primary_user_email")
+		return
+	case name == nil || name["givenName"] == nil || name["familyName"] == nil:
+		writeFakeBadRequest(w, "Invalid Given/Family Name: FamilyName")
+		return
+	case fakeString(user, "password") == "":
+		writeFakeBadRequest(w, "Invalid Password")
+		return
+	case !f.isCustomerDomain(primaryEmail):
+		writeFakeBadRequest(w, "Domain not found.")
+		return
+	case f.emailInUse(primaryEmail):
+		writeFakeConflict(w, "Entity already exists.")
+		return
+	}
+
+	f.defaultUser(user)
+	writeFakeLatest(w, f.put(fakeUsers, fakeString(user, "id"), user))
+}
+
+func (f *fakeWorkspace) getUser(w http.ResponseWriter, r *http.Request, params []string) {
+	_, obj, ok := f.findByEmailOrId(fakeUsers, params[0])
+	if !ok {
+		writeFakeNotFound(w, "userKey")
+		return
+	}
+
+	writeFakeObject(w, r, obj)
+}
+
+func (f *fakeWorkspace) updateUser(w http.ResponseWriter, r *http.Request, params []string) {
+	id, obj, ok := f.findByEmailOrId(fakeUsers, params[0])
+	if !ok {
+		writeFakeNotFound(w, "userKey")
+		return
+	}
+
+	oldEmail := fakeString(fakeFields(obj), "primaryEmail")
+
+	user := map[string]interface{}{}
+	if !mergeFakeRequest(w, r, obj, &user) {
+		return
+	}
+
+	if newEmail := fakeString(user, "primaryEmail"); !strings.EqualFold(newEmail, oldEmail) {
+		if !f.isCustomerDomain(newEmail) {
+			writeFakeBadRequest(w, "Domain not found.")
+			return
+		}
+		if f.emailInUse(newEmail) {
+			writeFakeConflict(w, "Entity already exists.")
+			return
+		}
+	}
+
+	f.normalizeUser(user)
+	writeFakeLatest(w, f.put(fakeUsers, id, user))
+}
+
+func (f *fakeWorkspace) deleteUser(w http.ResponseWriter, r *http.Request, params []string) {
+	id, obj, ok := f.findByEmailOrId(fakeUsers, params[0])
+	if !ok {
+		writeFakeNotFound(w, "userKey")
+		return
+	}
+
+	f.remove(fakeUsers, id)
+	f.removeMemberships(id)
+	delete(f.objects, fakeSendAsPrefix+strings.ToLower(fakeString(fakeFields(obj), "primaryEmail")))
+
+	for _, key := range f.keys(fakeRoleAssignments) {
+		assignment, _ := f.get(fakeRoleAssignments, key)
+		if fakeFields(assignment)["assignedTo"] == id {
+			f.remove(fakeRoleAssignments, key)
+		}
+	}
+
+	writeFakeNoContent(w)
+}
+
+func (f *fakeWorkspace) makeAdmin(w http.ResponseWriter, r *http.Request, params []string) {
+	id, obj, ok := f.findByEmailOrId(fakeUsers, params[0])
+	if !ok {
+		writeFakeNotFound(w, "userKey")
+		return
+	}
+
+	var makeAdmin struct {
+		Status bool `json:"status"`
+	}
+	if !decodeFakeRequest(w, r, &makeAdmin) {
+		return
+	}
+
+	user := fakeFields(obj)
+	user["isAdmin"] = makeAdmin.Status
+	f.put(fakeUsers, id, user)
+
+	writeFakeNoContent(w)
+}
+
+// listUsers supports the customer, domain, query, maxResults and pageToken
+// parameters.
+func (f *fakeWorkspace) listUsers(w http.ResponseWriter, r *http.Request, params []string) {
+	customer, domain := r.URL.Query().Get("customer"), r.URL.Query().Get("domain")
+	if customer == "" && domain == "" {
+		writeFakeBadRequest(w, "Bad Request")
+		return
+	}
+	if customer != "" && !f.checkCustomer(w, customer) {
+		return
+	}
+
+	var users []map[string]interface{}
+	for _, key := range f.keys(fakeUsers) {
+		obj, _ := f.get(fakeUsers, key)
+		user := fakeFields(obj)
+
+		email := fakeString(user, "primaryEmail")
+		if domain != "" && !strings.EqualFold(email[strings.LastIndex(email, "@")+1:], domain) {
+			continue
+		}
+		if !fakeUserMatchesQuery(user, r.URL.Query().Get("query")) {
+			continue
+		}
+
+		users = append(users, user)
+	}
+
+	page, next := paginateFake(r, fakeRawItems(users))
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"kind":          "admin#directory#users",
+		"users":         page,
+		"nextPageToken": next,
+	})
+}
+
+// fakeUserMatchesQuery supports queries of `field:value` terms, where the value
+// may end with `*` to match a prefix.
+func fakeUserMatchesQuery(user map[string]interface{}, query string) bool {
+	name, _ := user["name"].(map[string]interface{})
+	if name == nil {
+		name = map[string]interface{}{}
+	}
+
+	values := map[string][]string{
+		"email":       append([]string{fakeString(user, "primaryEmail")}, fakeStrings(user, "aliases")...),
+		"name":        {fakeString(name, "fullName")},
+		"givenname":   {fakeString(name, "givenName")},
+		"familyname":  {fakeString(name, "familyName")},
+		"orgunitpath": {fakeString(user, "orgUnitPath")},
+		"isadmin":     {strconv.FormatBool(user["isAdmin"] == true)},
+		"issuspended": {strconv.FormatBool(user["suspended"] == true)},
+	}
+
+	for _, term := range strings.Fields(query) {
+		sep := strings.IndexAny(term, ":=")
+		if sep < 0 {
+			continue
+		}
+
+		field := strings.ToLower(term[:sep])
+		value := strings.ToLower(strings.Trim(term[sep+1:], `'"`))
+
+		candidates, ok := values[field]
+		if !ok {
+			continue
+		}
+
+		matched := false
+		for _, candidate := range candidates {
+			candidate = strings.ToLower(candidate)
+			if strings.HasSuffix(value, "*") && strings.HasPrefix(candidate, strings.TrimSuffix(value, "*")) || candidate == value {
+				matched = true
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *fakeWorkspace) insertGroup(w http.ResponseWriter, r *http.Request, params []string) {
+	group := map[string]interface{}{}
+	if !decodeFakeRequest(w, r, &group) {
+		return
+	}
+
+	email := fakeString(group, "email")
+	switch {
+	case email == "":
+		writeFakeBadRequest(w, "Missing required field: email")
+		return
+	case !f.isCustomerDomain(email):
+		writeFakeBadRequest(w, "Domain not found.")
+		return
+	case f.emailInUse(email):
+		writeFakeConflict(w, "Entity already exists.")
+		return
+	}
+
+	fakeDefaults(group, map[string]interface{}{
+		"name":        email[:strings.LastIndex(email, "@")],
+		"description": "",
+	})
+	group["kind"] = "admin#directory#group"
+	group["id"] = fmt.Sprintf("0%014d", f.newId())
+	group["adminCreated"] = true
+
+	id := fakeString(group, "id")
+	obj := f.put(fakeGroups, id, group)
+	f.defaultGroupSettings(group)
+
+	writeFakeLatest(w, obj)
+}
+
+func (f *fakeWorkspace) getGroup(w http.ResponseWriter, r *http.Request, params []string) {
+	id, obj, ok := f.findByEmailOrId(fakeGroups, params[0])
+	if !ok {
+		writeFakeNotFound(w, "groupKey")
+		return
+	}
+
+	writeFakeObjectWith(w, r, obj, f.groupMembersCount(id))
+}
+
+func (f *fakeWorkspace) updateGroup(w http.ResponseWriter, r *http.Request, params []string) {
+	id, obj, ok := f.findByEmailOrId(fakeGroups, params[0])
+	if !ok {
+		writeFakeNotFound(w, "groupKey")
+		return
+	}
+
+	oldEmail := fakeString(fakeFields(obj), "email")
+
+	group := map[string]interface{}{}
+	if !mergeFakeRequest(w, r, obj, &group) {
+		return
+	}
+
+	newEmail := fakeString(group, "email")
+	if !strings.EqualFold(newEmail, oldEmail) {
+		if !f.isCustomerDomain(newEmail) {
+			writeFakeBadRequest(w, "Domain not found.")
+			return
+		}
+		if f.emailInUse(newEmail) {
+			writeFakeConflict(w, "Entity already exists.")
+			return
+		}
+	}
+
+	obj = f.put(fakeGroups, id, group)
+	f.syncGroupSettings(oldEmail, group)
+
+	writeFakeLatest(w, obj)
+}
+
+func (f *fakeWorkspace) deleteGroup(w http.ResponseWriter, r *http.Request, params []string) {
+	id, obj, ok := f.findByEmailOrId(fakeGroups, params[0])
+	if !ok {
+		writeFakeNotFound(w, "groupKey")
+		return
+	}
+
+	f.remove(fakeGroups, id)
+	delete(f.objects, fakeMembersPrefix+id)
+	f.remove(fakeGroupSettings, strings.ToLower(fakeString(fakeFields(obj), "email")))
+	f.removeMemberships(id)
+	f.removeChromePolicies("groups/" + id)
+
+	writeFakeNoContent(w)
+}
+
+// listGroups supports the customer, domain, userKey, maxResults and pageToken
+// parameters.
+func (f *fakeWorkspace) listGroups(w http.ResponseWriter, r *http.Request, params []string) {
+	customer, domain, userKey := r.URL.Query().Get("customer"), r.URL.Query().Get("domain"), r.URL.Query().Get("userKey")
+	if customer == "" && domain == "" && userKey == "" {
+		writeFakeBadRequest(w, "Bad Request")
+		return
+	}
+	if customer != "" && !f.checkCustomer(w, customer) {
+		return
+	}
+
+	memberId := ""
+	if userKey != "" {
+		id, _, ok := f.findByEmailOrId(fakeUsers, userKey)
+		if !ok {
+			id, _, ok = f.findByEmailOrId(fakeGroups, userKey)
+		}
+		if !ok {
+			writeFakeNotFound(w, "userKey")
+			return
+		}
+		memberId = id
+	}
+
+	var groups []map[string]interface{}
+	for _, key := range f.keys(fakeGroups) {
+		obj, _ := f.get(fakeGroups, key)
+		group := fakeFields(obj)
+
+		email := fakeString(group, "email")
+		if domain != "" && !strings.EqualFold(email[strings.LastIndex(email, "@")+1:], domain) {
+			continue
+		}
+		if memberId != "" {
+			if _, isMember := f.get(fakeMembersPrefix+key, memberId); !isMember {
+				continue
+			}
+		}
+
+		f.groupMembersCount(key)(group)
+		groups = append(groups, group)
+	}
+
+	page, next := paginateFake(r, fakeRawItems(groups))
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"kind":          "admin#directory#groups",
+		"groups":        page,
+		"nextPageToken": next,
+	})
+}
+
+// groupMembersCount sets the number of direct members of the group, which
+// doesn't change its etag.
+func (f *fakeWorkspace) groupMembersCount(id string) func(map[string]interface{}) {
+	return func(group map[string]interface{}) {
+		group["directMembersCount"] = strconv.Itoa(len(f.collection(fakeMembersPrefix + id)))
+	}
+}
+
+// insertAlias, listAliases and deleteAlias serve the aliases of users or
+// groups. A change to the aliases is a change to the user or group.
+func (f *fakeWorkspace) insertAlias(collection string) func(w http.ResponseWriter, r *http.Request, params []string) {
+	return func(w http.ResponseWriter, r *http.Request, params []string) {
+		id, obj, ok := f.findByEmailOrId(collection, params[0])
+		if !ok {
+			writeFakeNotFound(w, strings.TrimSuffix(collection, "s")+"Key")
+			return
+		}
+
+		var alias struct {
+			Alias string `json:"alias"`
+		}
+		if !decodeFakeRequest(w, r, &alias) {
+			return
+		}
+
+		switch {
+		case alias.Alias == "":
+			writeFakeBadRequest(w, "Missing required field: alias")
+			return
+		case !f.isCustomerDomain(alias.Alias):
+			writeFakeBadRequest(w, "Domain not found.")
+			return
+		case f.emailInUse(alias.Alias):
+			writeFakeConflict(w, "Entity already exists.")
+			return
+		}
+
+		fields := fakeFields(obj)
+		fields["aliases"] = append(fakeStrings(fields, "aliases"), alias.Alias)
+		if collection == fakeUsers {
+			f.normalizeUser(fields)
+		}
+		f.put(collection, id, fields)
+
+		writeFakeJSON(w, http.StatusOK, fakeAlias(fields, collection, alias.Alias, f.newEtag()))
+	}
+}
+
+func (f *fakeWorkspace) listAliases(collection string) func(w http.ResponseWriter, r *http.Request, params []string) {
+	return func(w http.ResponseWriter, r *http.Request, params []string) {
+		_, obj, ok := f.findByEmailOrId(collection, params[0])
+		if !ok {
+			writeFakeNotFound(w, strings.TrimSuffix(collection, "s")+"Key")
+			return
+		}
+
+		fields := fakeFields(obj)
+
+		aliases := []interface{}{}
+		for _, alias := range fakeStrings(fields, "aliases") {
+			aliases = append(aliases, fakeAlias(fields, collection, alias, fakeString(fields, "etag")))
+		}
+
+		writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+			"kind":    "admin#directory#aliases",
+			"aliases": aliases,
+		})
+	}
+}
+
+func (f *fakeWorkspace) deleteAlias(collection string) func(w http.ResponseWriter, r *http.Request, params []string) {
+	return func(w http.ResponseWriter, r *http.Request, params []string) {
+		id, obj, ok := f.findByEmailOrId(collection, params[0])
+		if !ok {
+			writeFakeNotFound(w, strings.TrimSuffix(collection, "s")+"Key")
+			return
+		}
+
+		fields := fakeFields(obj)
+
+		var aliases []string
+		for _, alias := range fakeStrings(fields, "aliases") {
+			if !strings.EqualFold(alias, params[1]) {
+				aliases = append(aliases, alias)
+			}
+		}
+		if len(aliases) == len(fakeStrings(fields, "aliases")) {
+			writeFakeNotFound(w, "alias")
+			return
+		}
+
+		fields["aliases"] = aliases
+		if collection == fakeUsers {
+			f.normalizeUser(fields)
+		}
+		f.put(collection, id, fields)
+
+		writeFakeNoContent(w)
+	}
+}
+
+func fakeAlias(owner map[string]interface{}, collection, alias, etag string) map[string]interface{} {
+	return map[string]interface{}{
+		"kind":         "admin#directory#alias",
+		"id":           owner["id"],
+		"primaryEmail": owner[fakePrimaryEmailField(collection)],
+		"alias":        alias,
+		"etag":         etag,
+	}
+}
+
+// findMember finds the member of the group by its id or email address.
+func (f *fakeWorkspace) findMember(groupId, key string) (*fakeObject, bool) {
+	if obj, ok := f.get(fakeMembersPrefix+groupId, key); ok {
+		return obj, true
+	}
+
+	_, obj, ok := f.find(fakeMembersPrefix+groupId, func(obj *fakeObject) bool {
+		return strings.EqualFold(fakeString(fakeFields(obj), "email"), key)
+	})
+
+	return obj, ok
+}
+
+// removeMemberships removes the user or group from every group it's a member of.
+func (f *fakeWorkspace) removeMemberships(memberId string) {
+	for _, groupId := range f.keys(fakeGroups) {
+		f.remove(fakeMembersPrefix+groupId, memberId)
+	}
+}
+
+func (f *fakeWorkspace) insertMember(w http.ResponseWriter, r *http.Request, params []string) {
+	groupId, _, ok := f.findByEmailOrId(fakeGroups, params[0])
+	if !ok {
+		writeFakeNotFound(w, "groupKey")
+		return
+	}
+
+	member := map[string]interface{}{}
+	if !decodeFakeRequest(w, r, &member) {
+		return
+	}
+
+	// the customer is added by its ID, and has no email address
+	customer := fakeString(member, "type") == "CUSTOMER"
+
+	email := fakeString(member, "email")
+	if email == "" && !customer {
+		writeFakeBadRequest(w, "Missing required field: memberKey")
+		return
+	}
+
+	fakeDefaults(member, map[string]interface{}{
+		"role":              "MEMBER",
+		"delivery_settings": "ALL_MAIL",
+	})
+	if !stringInSlice([]string{"OWNER", "MANAGER", "MEMBER"}, fakeString(member, "role")) {
+		writeFakeBadRequest(w, "Invalid Input: role")
+		return
+	}
+
+	if customer {
+		if email != "" || fakeString(member, "id") != fakeCustomerId {
+			writeFakeBadRequest(w, "Invalid Input: memberKey")
+			return
+		}
+		member["status"] = "ACTIVE"
+	} else if id, obj, ok := f.findByEmailOrId(fakeUsers, email); ok {
+		member["id"] = id
+		member["email"] = fakeString(fakeFields(obj), "primaryEmail")
+		member["type"] = "USER"
+		member["status"] = "ACTIVE"
+	} else if id, obj, ok := f.findByEmailOrId(fakeGroups, email); ok {
+		if id == groupId {
+			writeFakeBadRequest(w, "Invalid Input: memberKey")
+			return
+		}
+		member["id"] = id
+		member["email"] = fakeString(fakeFields(obj), "email")
+		member["type"] = "GROUP"
+	} else if f.isCustomerDomain(email) {
+		writeFakeNotFound(w, "memberKey")
+		return
+	} else {
+		member["id"] = fmt.Sprintf("1%020d", f.newId())
+		member["type"] = "USER"
+	}
+	member["kind"] = "admin#directory#member"
+
+	key := fakeString(member, "email")
+	if customer {
+		key = fakeString(member, "id")
+	}
+	if _, exists := f.findMember(groupId, key); exists {
+		writeFakeConflict(w, "Member already exists.")
+		return
+	}
+
+	writeFakeLatest(w, f.put(fakeMembersPrefix+groupId, fakeString(member, "id"), member))
+}
+
+func (f *fakeWorkspace) getMember(w http.ResponseWriter, r *http.Request, params []string) {
+	groupId, _, ok := f.findByEmailOrId(fakeGroups, params[0])
+	if !ok {
+		writeFakeNotFound(w, "groupKey")
+		return
+	}
+
+	obj, ok := f.findMember(groupId, params[1])
+	if !ok {
+		writeFakeNotFound(w, "memberKey")
+		return
+	}
+
+	writeFakeObject(w, r, obj)
+}
+
+func (f *fakeWorkspace) updateMember(w http.ResponseWriter, r *http.Request, params []string) {
+	groupId, _, ok := f.findByEmailOrId(fakeGroups, params[0])
+	if !ok {
+		writeFakeNotFound(w, "groupKey")
+		return
+	}
+
+	obj, ok := f.findMember(groupId, params[1])
+	if !ok {
+		writeFakeNotFound(w, "memberKey")
+		return
+	}
+
+	member := map[string]interface{}{}
+	if !mergeFakeRequest(w, r, obj, &member) {
+		return
+	}
+	if !stringInSlice([]string{"OWNER", "MANAGER", "MEMBER"}, fakeString(member, "role")) {
+		writeFakeBadRequest(w, "Invalid Input: role")
+		return
+	}
+
+	// the member itself can't be changed
+	previous := fakeFields(obj)
+	for _, k := range []string{"id", "email", "type", "status"} {
+		if v, ok := previous[k]; ok {
+			member[k] = v
+		}
+	}
+
+	writeFakeLatest(w, f.put(fakeMembersPrefix+groupId, fakeString(member, "id"), member))
+}
+
+func (f *fakeWorkspace) deleteMember(w http.ResponseWriter, r *http.Request, params []string) {
+	groupId, _, ok := f.findByEmailOrId(fakeGroups, params[0])
+	if !ok {
+		writeFakeNotFound(w, "groupKey")
+		return
+	}
+
+	obj, ok := f.findMember(groupId, params[1])
+	if !ok {
+		writeFakeNotFound(w, "memberKey")
+		return
+	}
+
+	f.remove(fakeMembersPrefix+groupId, fakeString(fakeFields(obj), "id"))
+	writeFakeNoContent(w)
+}
+
+// listMembers supports the roles, maxResults and pageToken parameters.
+func (f *fakeWorkspace) listMembers(w http.ResponseWriter, r *http.Request, params []string) {
+	groupId, _, ok := f.findByEmailOrId(fakeGroups, params[0])
+	if !ok {
+		writeFakeNotFound(w, "groupKey")
+		return
+	}
+
+	var roles []string
+	if v := r.URL.Query().Get("roles"); v != "" {
+		roles = strings.Split(strings.ToUpper(v), ",")
+	}
+
+	var members []map[string]interface{}
+	for _, key := range f.keys(fakeMembersPrefix + groupId) {
+		obj, _ := f.get(fakeMembersPrefix+groupId, key)
+		member := fakeFields(obj)
+
+		if roles != nil && !stringInSlice(roles, fakeString(member, "role")) {
+			continue
+		}
+		members = append(members, member)
+	}
+
+	page, next := paginateFake(r, fakeRawItems(members))
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"kind":          "admin#directory#members",
+		"members":       page,
+		"nextPageToken": next,
+	})
+}
+
+// hasMember includes members of the group's member groups.
+func (f *fakeWorkspace) hasMember(w http.ResponseWriter, r *http.Request, params []string) {
+	groupId, _, ok := f.findByEmailOrId(fakeGroups, params[0])
+	if !ok {
+		writeFakeNotFound(w, "groupKey")
+		return
+	}
+
+	memberId, _, ok := f.findByEmailOrId(fakeUsers, params[1])
+	if !ok {
+		memberId, _, ok = f.findByEmailOrId(fakeGroups, params[1])
+	}
+	if !ok {
+		writeFakeNotFound(w, "memberKey")
+		return
+	}
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"isMember": f.isMember(groupId, memberId, map[string]bool{}),
+	})
+}
+
+func (f *fakeWorkspace) isMember(groupId, memberId string, visited map[string]bool) bool {
+	if visited[groupId] {
+		return false
+	}
+	visited[groupId] = true
+
+	if _, ok := f.get(fakeMembersPrefix+groupId, memberId); ok {
+		return true
+	}
+
+	for _, key := range f.keys(fakeMembersPrefix + groupId) {
+		obj, _ := f.get(fakeMembersPrefix+groupId, key)
+		if fakeFields(obj)["type"] == "GROUP" && f.isMember(key, memberId, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findOrgUnit finds the org unit by its `id:` prefixed id or its path, with or
+// without the leading slash.
+func (f *fakeWorkspace) findOrgUnit(key string) (string, *fakeObject, bool) {
+	if strings.HasPrefix(key, "id:") {
+		obj, ok := f.get(fakeOrgUnits, key)
+		return key, obj, ok
+	}
+
+	orgUnitPath := "/" + strings.Trim(key, "/")
+	return f.find(fakeOrgUnits, func(obj *fakeObject) bool {
+		return strings.EqualFold(fakeString(fakeFields(obj), "orgUnitPath"), orgUnitPath)
+	})
+}
+
+func (f *fakeWorkspace) insertOrgUnit(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	orgUnit := map[string]interface{}{}
+	if !decodeFakeRequest(w, r, &orgUnit) {
+		return
+	}
+
+	if fakeString(orgUnit, "name") == "" {
+		writeFakeBadRequest(w, "Missing required field: name")
+		return
+	}
+
+	parentKey := fakeString(orgUnit, "parentOrgUnitId")
+	if parentKey == "" {
+		parentKey = fakeString(orgUnit, "parentOrgUnitPath")
+	}
+	_, parent, ok := f.findOrgUnit(parentKey)
+	if parentKey == "" || !ok {
+		writeFakeBadRequest(w, "Invalid Parent Orgunit Id")
+		return
+	}
+
+	id := fmt.Sprintf("id:03ph8a2z%08x", f.newId())
+	orgUnit["kind"] = "admin#directory#orgUnit"
+	orgUnit["orgUnitId"] = id
+	fakeDefaults(orgUnit, map[string]interface{}{
+		"description":      "",
+		"blockInheritance": false,
+	})
+	if !f.setOrgUnitParent(w, orgUnit, fakeFields(parent)) {
+		return
+	}
+
+	writeFakeLatest(w, f.put(fakeOrgUnits, id, orgUnit))
+}
+
+// setOrgUnitParent sets the fields derived from the parent of the org unit.
+func (f *fakeWorkspace) setOrgUnitParent(w http.ResponseWriter, orgUnit, parent map[string]interface{}) bool {
+	orgUnitPath := path.Join(fakeString(parent, "orgUnitPath"), fakeString(orgUnit, "name"))
+
+	if _, existing, ok := f.findOrgUnit(orgUnitPath); ok && fakeString(fakeFields(existing), "orgUnitId") != fakeString(orgUnit, "orgUnitId") {
+		writeFakeConflict(w, "Invalid Ou Id")
+		return false
+	}
+
+	orgUnit["parentOrgUnitId"] = parent["orgUnitId"]
+	orgUnit["parentOrgUnitPath"] = parent["orgUnitPath"]
+	orgUnit["orgUnitPath"] = orgUnitPath
+
+	return true
+}
+
+func (f *fakeWorkspace) getOrgUnit(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	id, obj, ok := f.findOrgUnit(params[1])
+	if !ok || id == fakeRootOrgUnitId {
+		writeFakeNotFound(w, "orgunit")
+		return
+	}
+
+	writeFakeObject(w, r, obj)
+}
+
+func (f *fakeWorkspace) updateOrgUnit(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	id, obj, ok := f.findOrgUnit(params[1])
+	if !ok || id == fakeRootOrgUnitId {
+		writeFakeNotFound(w, "orgunit")
+		return
+	}
+
+	previous := fakeFields(obj)
+
+	orgUnit := map[string]interface{}{}
+	if !mergeFakeRequest(w, r, obj, &orgUnit) {
+		return
+	}
+
+	parentKey := fakeString(orgUnit, "parentOrgUnitId")
+	if fakeString(orgUnit, "parentOrgUnitPath") != fakeString(previous, "parentOrgUnitPath") {
+		parentKey = fakeString(orgUnit, "parentOrgUnitPath")
+	}
+	parentId, parent, ok := f.findOrgUnit(parentKey)
+	if !ok || parentId == id {
+		writeFakeBadRequest(w, "Invalid Parent Orgunit Id")
+		return
+	}
+	if !f.setOrgUnitParent(w, orgUnit, fakeFields(parent)) {
+		return
+	}
+
+	obj = f.put(fakeOrgUnits, id, orgUnit)
+
+	// moving or renaming an org unit changes the path of those below it
+	if fakeString(orgUnit, "orgUnitPath") != fakeString(previous, "orgUnitPath") {
+		f.moveOrgUnitChildren(orgUnit)
+	}
+
+	writeFakeLatest(w, obj)
+}
+
+func (f *fakeWorkspace) moveOrgUnitChildren(parent map[string]interface{}) {
+	for _, key := range f.keys(fakeOrgUnits) {
+		obj, _ := f.get(fakeOrgUnits, key)
+		child := fakeFields(obj)
+		if child["parentOrgUnitId"] != parent["orgUnitId"] {
+			continue
+		}
+
+		child["parentOrgUnitPath"] = parent["orgUnitPath"]
+		child["orgUnitPath"] = path.Join(fakeString(parent, "orgUnitPath"), fakeString(child, "name"))
+		f.put(fakeOrgUnits, key, child)
+		f.moveOrgUnitChildren(child)
+	}
+}
+
+func (f *fakeWorkspace) deleteOrgUnit(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	id, obj, ok := f.findOrgUnit(params[1])
+	if !ok || id == fakeRootOrgUnitId {
+		writeFakeNotFound(w, "orgunit")
+		return
+	}
+
+	orgUnitPath := fakeString(fakeFields(obj), "orgUnitPath")
+
+	if _, _, hasChildren := f.find(fakeOrgUnits, func(child *fakeObject) bool {
+		return fakeFields(child)["parentOrgUnitId"] == id
+	}); hasChildren {
+		writeFakeBadRequest(w, "Org unit has sub org units")
+		return
+	}
+	if _, _, hasUsers := f.find(fakeUsers, func(user *fakeObject) bool {
+		return strings.EqualFold(fakeString(fakeFields(user), "orgUnitPath"), orgUnitPath)
+	}); hasUsers {
+		writeFakeBadRequest(w, "Org unit contains users")
+		return
+	}
+
+	f.remove(fakeOrgUnits, id)
+	f.removeChromePolicies(fakeOrgUnitTarget(id))
+
+	writeFakeNoContent(w)
+}
+
+// listOrgUnits supports the orgUnitPath and type parameters.
+func (f *fakeWorkspace) listOrgUnits(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	parentKey := r.URL.Query().Get("orgUnitPath")
+	if parentKey == "" {
+		parentKey = "/"
+	}
+	parentId, parent, ok := f.findOrgUnit(parentKey)
+	if !ok {
+		writeFakeNotFound(w, "orgunit")
+		return
+	}
+	parentPath := fakeString(fakeFields(parent), "orgUnitPath")
+
+	listType := r.URL.Query().Get("type")
+
+	orgUnits := []map[string]interface{}{}
+	for _, key := range f.keys(fakeOrgUnits) {
+		if key == fakeRootOrgUnitId {
+			continue
+		}
+
+		obj, _ := f.get(fakeOrgUnits, key)
+		orgUnit := fakeFields(obj)
+
+		isDescendant := strings.HasPrefix(fakeString(orgUnit, "orgUnitPath"), strings.TrimSuffix(parentPath, "/")+"/")
+
+		var inScope bool
+		switch listType {
+		case "all":
+			inScope = isDescendant
+		case "allIncludingParent":
+			inScope = isDescendant || key == parentId
+		default:
+			inScope = orgUnit["parentOrgUnitId"] == parentId
+		}
+		if !inScope {
+			continue
+		}
+
+		orgUnits = append(orgUnits, orgUnit)
+	}
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"kind":              "admin#directory#org_units",
+		"organizationUnits": orgUnits,
+	})
+}
+
+func (f *fakeWorkspace) insertDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	domain := map[string]interface{}{}
+	if !decodeFakeRequest(w, r, &domain) {
+		return
+	}
+
+	name := strings.ToLower(fakeString(domain, "domainName"))
+	if name == "" {
+		writeFakeBadRequest(w, "Missing required field: domainName")
+		return
+	}
+	if f.isCustomerDomain("@" + name) {
+		writeFakeConflict(w, "Entity already exists.")
+		return
+	}
+
+	domain["kind"] = "admin#directory#domain"
+	domain["domainName"] = name
+	domain["isPrimary"] = false
+	domain["verified"] = false
+	domain["creationTime"] = strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10)
+
+	writeFakeLatest(w, f.put(fakeDomains, name, domain))
+}
+
+func (f *fakeWorkspace) getDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	obj, ok := f.get(fakeDomains, strings.ToLower(params[1]))
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Domain not found.", "notFound")
+		return
+	}
+
+	writeFakeObject(w, r, obj)
+}
+
+func (f *fakeWorkspace) deleteDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	name := strings.ToLower(params[1])
+	obj, ok := f.get(fakeDomains, name)
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Domain not found.", "notFound")
+		return
+	}
+	if fakeFields(obj)["isPrimary"] == true {
+		writeFakeBadRequest(w, "Cannot delete primary domain")
+		return
+	}
+
+	f.remove(fakeDomains, name)
+	for _, key := range f.keys(fakeDomainAliases) {
+		alias, _ := f.get(fakeDomainAliases, key)
+		if strings.EqualFold(fakeString(fakeFields(alias), "parentDomainName"), name) {
+			f.remove(fakeDomainAliases, key)
+		}
+	}
+
+	writeFakeNoContent(w)
+}
+
+func (f *fakeWorkspace) listDomains(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"kind":    "admin#directory#domains",
+		"domains": f.list(fakeDomains),
+	})
+}
+
+func (f *fakeWorkspace) insertDomainAlias(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	domainAlias := map[string]interface{}{}
+	if !decodeFakeRequest(w, r, &domainAlias) {
+		return
+	}
+
+	name := strings.ToLower(fakeString(domainAlias, "domainAliasName"))
+	if name == "" {
+		writeFakeBadRequest(w, "Missing required field: domainAliasName")
+		return
+	}
+	if _, ok := f.get(fakeDomains, strings.ToLower(fakeString(domainAlias, "parentDomainName"))); !ok {
+		writeFakeBadRequest(w, "Invalid Input: parentDomainName")
+		return
+	}
+	if f.isCustomerDomain("@" + name) {
+		writeFakeConflict(w, "Entity already exists.")
+		return
+	}
+
+	domainAlias["kind"] = "admin#directory#domainAlias"
+	domainAlias["domainAliasName"] = name
+	domainAlias["verified"] = false
+	domainAlias["creationTime"] = strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10)
+
+	writeFakeLatest(w, f.put(fakeDomainAliases, name, domainAlias))
+}
+
+func (f *fakeWorkspace) getDomainAlias(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	obj, ok := f.get(fakeDomainAliases, strings.ToLower(params[1]))
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Domain alias not found.", "notFound")
+		return
+	}
+
+	writeFakeObject(w, r, obj)
+}
+
+func (f *fakeWorkspace) deleteDomainAlias(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	name := strings.ToLower(params[1])
+	if _, ok := f.get(fakeDomainAliases, name); !ok {
+		writeFakeError(w, http.StatusNotFound, "Domain alias not found.", "notFound")
+		return
+	}
+
+	f.remove(fakeDomainAliases, name)
+	writeFakeNoContent(w)
+}
+
+// listDomainAliases supports the parentDomainName parameter.
+func (f *fakeWorkspace) listDomainAliases(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	parent := r.URL.Query().Get("parentDomainName")
+
+	domainAliases := []map[string]interface{}{}
+	for _, key := range f.keys(fakeDomainAliases) {
+		obj, _ := f.get(fakeDomainAliases, key)
+		domainAlias := fakeFields(obj)
+		if parent != "" && !strings.EqualFold(fakeString(domainAlias, "parentDomainName"), parent) {
+			continue
+		}
+		domainAliases = append(domainAliases, domainAlias)
+	}
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"kind":          "admin#directory#domainAliases",
+		"domainAliases": domainAliases,
+	})
+}
+
+func (f *fakeWorkspace) listPrivileges(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"kind":  "admin#directory#privileges",
+		"etag":  `"fake-privileges"`,
+		"items": fakePrivileges(),
+	})
+}
+
+// checkRolePrivileges responds with an error if any of the privileges of the
+// role aren't in the catalog.
+func (f *fakeWorkspace) checkRolePrivileges(w http.ResponseWriter, role map[string]interface{}) bool {
+	privileges, _ := role["rolePrivileges"].([]interface{})
+	if len(privileges) == 0 {
+		writeFakeBadRequest(w, "Invalid Role privileges")
+		return false
+	}
+
+	for _, p := range privileges {
+		privilege, _ := p.(map[string]interface{})
+		if _, ok := fakePrivilegeCatalog()[fakePrivilegeKey(fakeString(privilege, "serviceId"), fakeString(privilege, "privilegeName"))]; !ok {
+			writeFakeBadRequest(w, "Invalid Role privileges")
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *fakeWorkspace) findRoleByName(name string) (string, bool) {
+	key, _, ok := f.find(fakeRoles, func(obj *fakeObject) bool {
+		return fakeString(fakeFields(obj), "roleName") == name
+	})
+
+	return key, ok
+}
+
+func (f *fakeWorkspace) insertRole(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	role := map[string]interface{}{}
+	if !decodeFakeRequest(w, r, &role) {
+		return
+	}
+
+	if fakeString(role, "roleName") == "" {
+		writeFakeBadRequest(w, "Missing required field: roleName")
+		return
+	}
+	if _, exists := f.findRoleByName(fakeString(role, "roleName")); exists {
+		writeFakeConflict(w, "Entity already exists.")
+		return
+	}
+	if !f.checkRolePrivileges(w, role) {
+		return
+	}
+
+	role["kind"] = "admin#directory#role"
+	role["roleId"] = strconv.FormatInt(f.newId()+fakeRoleIdOffset, 10)
+	role["isSystemRole"] = false
+	role["isSuperAdminRole"] = false
+
+	writeFakeLatest(w, f.put(fakeRoles, fakeString(role, "roleId"), role))
+}
+
+func (f *fakeWorkspace) getRole(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	obj, ok := f.get(fakeRoles, params[1])
+	if !ok {
+		writeFakeNotFound(w, "roleId")
+		return
+	}
+
+	writeFakeObject(w, r, obj)
+}
+
+func (f *fakeWorkspace) updateRole(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	obj, ok := f.get(fakeRoles, params[1])
+	if !ok {
+		writeFakeNotFound(w, "roleId")
+		return
+	}
+	previous := fakeFields(obj)
+	if previous["isSystemRole"] == true {
+		writeFakeBadRequest(w, "Cannot modify a system role")
+		return
+	}
+
+	role := map[string]interface{}{}
+	if !mergeFakeRequest(w, r, obj, &role) {
+		return
+	}
+
+	if fakeString(role, "roleName") != fakeString(previous, "roleName") {
+		if _, exists := f.findRoleByName(fakeString(role, "roleName")); exists {
+			writeFakeConflict(w, "Entity already exists.")
+			return
+		}
+	}
+	if !f.checkRolePrivileges(w, role) {
+		return
+	}
+
+	for _, k := range []string{"kind", "roleId", "isSystemRole", "isSuperAdminRole"} {
+		role[k] = previous[k]
+	}
+
+	writeFakeLatest(w, f.put(fakeRoles, params[1], role))
+}
+
+func (f *fakeWorkspace) deleteRole(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	obj, ok := f.get(fakeRoles, params[1])
+	if !ok {
+		writeFakeNotFound(w, "roleId")
+		return
+	}
+	if fakeFields(obj)["isSystemRole"] == true {
+		writeFakeBadRequest(w, "Cannot delete a system role")
+		return
+	}
+
+	if _, _, assigned := f.find(fakeRoleAssignments, func(assignment *fakeObject) bool {
+		return fakeString(fakeFields(assignment), "roleId") == params[1]
+	}); assigned {
+		writeFakeBadRequest(w, "Cannot delete a role that is assigned")
+		return
+	}
+
+	f.remove(fakeRoles, params[1])
+	writeFakeNoContent(w)
+}
+
+func (f *fakeWorkspace) listRoles(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	page, next := paginateFake(r, f.list(fakeRoles))
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"kind":          "admin#directory#roles",
+		"items":         page,
+		"nextPageToken": next,
+	})
+}
+
+func (f *fakeWorkspace) insertRoleAssignment(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	assignment := map[string]interface{}{}
+	if !decodeFakeRequest(w, r, &assignment) {
+		return
+	}
+
+	role, ok := f.get(fakeRoles, fakeString(assignment, "roleId"))
+	if !ok {
+		writeFakeBadRequest(w, "Invalid Role")
+		return
+	}
+	if _, _, ok := f.findByEmailOrId(fakeUsers, fakeString(assignment, "assignedTo")); !ok {
+		writeFakeBadRequest(w, "Invalid Input: assignedTo")
+		return
+	}
+
+	fakeDefaults(assignment, map[string]interface{}{
+		"scopeType": "CUSTOMER",
+	})
+	switch fakeString(assignment, "scopeType") {
+	case "CUSTOMER":
+	case "ORG_UNIT":
+		if _, _, ok := f.findOrgUnit("id:" + fakeString(assignment, "orgUnitId")); !ok {
+			writeFakeBadRequest(w, "Invalid Input: orgUnitId")
+			return
+		}
+
+		// only roles whose every privilege can be scoped to an org unit
+		for _, p := range fakeFields(role)["rolePrivileges"].([]interface{}) {
+			privilege := p.(map[string]interface{})
+			if !fakePrivilegeCatalog()[fakePrivilegeKey(fakeString(privilege, "serviceId"), fakeString(privilege, "privilegeName"))] {
+				writeFakeBadRequest(w, "Role cannot be assigned to an org unit")
+				return
+			}
+		}
+	default:
+		writeFakeBadRequest(w, "Invalid Input: scopeType")
+		return
+	}
+
+	if _, _, exists := f.find(fakeRoleAssignments, func(obj *fakeObject) bool {
+		existing := fakeFields(obj)
+		return existing["roleId"] == assignment["roleId"] && existing["assignedTo"] == assignment["assignedTo"] &&
+			existing["scopeType"] == assignment["scopeType"] && existing["orgUnitId"] == assignment["orgUnitId"]
+	}); exists {
+		writeFakeConflict(w, "Entity already exists.")
+		return
+	}
+
+	assignment["kind"] = "admin#directory#roleAssignment"
+	assignment["roleAssignmentId"] = strconv.FormatInt(f.newId()+fakeRoleIdOffset, 10)
+	assignment["assigneeType"] = "user"
+
+	writeFakeLatest(w, f.put(fakeRoleAssignments, fakeString(assignment, "roleAssignmentId"), assignment))
+}
+
+func (f *fakeWorkspace) getRoleAssignment(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	obj, ok := f.get(fakeRoleAssignments, params[1])
+	if !ok {
+		writeFakeNotFound(w, "roleAssignmentId")
+		return
+	}
+
+	writeFakeObject(w, r, obj)
+}
+
+func (f *fakeWorkspace) deleteRoleAssignment(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	if _, ok := f.get(fakeRoleAssignments, params[1]); !ok {
+		writeFakeNotFound(w, "roleAssignmentId")
+		return
+	}
+
+	f.remove(fakeRoleAssignments, params[1])
+	writeFakeNoContent(w)
+}
+
+// listRoleAssignments supports the roleId, userKey, maxResults and pageToken
+// parameters.
+func (f *fakeWorkspace) listRoleAssignments(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	roleId := r.URL.Query().Get("roleId")
+
+	userId := ""
+	if userKey := r.URL.Query().Get("userKey"); userKey != "" {
+		id, _, ok := f.findByEmailOrId(fakeUsers, userKey)
+		if !ok {
+			writeFakeNotFound(w, "userKey")
+			return
+		}
+		userId = id
+	}
+
+	var assignments []map[string]interface{}
+	for _, key := range f.keys(fakeRoleAssignments) {
+		obj, _ := f.get(fakeRoleAssignments, key)
+		assignment := fakeFields(obj)
+
+		if roleId != "" && fakeString(assignment, "roleId") != roleId {
+			continue
+		}
+		if userId != "" && fakeString(assignment, "assignedTo") != userId {
+			continue
+		}
+		assignments = append(assignments, assignment)
+	}
+
+	page, next := paginateFake(r, fakeRawItems(assignments))
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"kind":          "admin#directory#roleAssignments",
+		"items":         page,
+		"nextPageToken": next,
+	})
+}
+
+// findSchema finds the schema by its id or name.
+func (f *fakeWorkspace) findSchema(key string) (string, *fakeObject, bool) {
+	if obj, ok := f.get(fakeSchemas, key); ok {
+		return key, obj, true
+	}
+
+	return f.find(fakeSchemas, func(obj *fakeObject) bool {
+		return fakeString(fakeFields(obj), "schemaName") == key
+	})
+}
+
+// setSchemaFields validates the fields of the schema and sets their defaults,
+// keeping the ids of existing fields.
+func (f *fakeWorkspace) setSchemaFields(w http.ResponseWriter, schema map[string]interface{}, previous map[string]interface{}) bool {
+	fieldIds := map[string]interface{}{}
+	if previousFields, ok := previous["fields"].([]interface{}); ok {
+		for _, field := range previousFields {
+			fieldIds[fakeString(field.(map[string]interface{}), "fieldName")] = field.(map[string]interface{})["fieldId"]
+		}
+	}
+
+	fields, _ := schema["fields"].([]interface{})
+	if len(fields) == 0 {
+		writeFakeBadRequest(w, "Missing required field: fields")
+		return false
+	}
+
+	for _, v := range fields {
+		field := v.(map[string]interface{})
+
+		if fakeString(field, "fieldName") == "" {
+			writeFakeBadRequest(w, "Missing required field: fieldName")
+			return false
+		}
+		if !stringInSlice([]string{"STRING", "INT64", "BOOL", "DOUBLE", "EMAIL", "PHONE", "DATE"}, fakeString(field, "fieldType")) {
+			writeFakeBadRequest(w, fmt.Sprintf("Invalid field type: %s", fakeString(field, "fieldType")))
+			return false
+		}
+
+		fakeDefaults(field, map[string]interface{}{
+			"readAccessType": "ALL_DOMAIN_USERS",
+			"indexed":        true,
+			"displayName":    field["fieldName"],
+		})
+		field["kind"] = "admin#directory#schema#fieldspec"
+		field["etag"] = f.newEtag()
+		if id, ok := fieldIds[fakeString(field, "fieldName")]; ok {
+			field["fieldId"] = id
+		} else {
+			field["fieldId"] = fmt.Sprintf("fakeField%08x", f.newId())
+		}
+	}
+
+	return true
+}
+
+func (f *fakeWorkspace) insertSchema(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	schema := map[string]interface{}{}
+	if !decodeFakeRequest(w, r, &schema) {
+		return
+	}
+
+	name := fakeString(schema, "schemaName")
+	if name == "" {
+		writeFakeBadRequest(w, "Missing required field: schemaName")
+		return
+	}
+	if _, _, exists := f.findSchema(name); exists {
+		writeFakeConflict(w, "Entity already exists.")
+		return
+	}
+	if !f.setSchemaFields(w, schema, nil) {
+		return
+	}
+
+	fakeDefaults(schema, map[string]interface{}{
+		"displayName": name,
+	})
+	schema["kind"] = "admin#directory#schema"
+	schema["schemaId"] = fmt.Sprintf("fakeSchema%08x", f.newId())
+
+	writeFakeLatest(w, f.put(fakeSchemas, fakeString(schema, "schemaId"), schema))
+}
+
+func (f *fakeWorkspace) getSchema(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	_, obj, ok := f.findSchema(params[1])
+	if !ok {
+		writeFakeNotFound(w, "schemaKey")
+		return
+	}
+
+	writeFakeObject(w, r, obj)
+}
+
+func (f *fakeWorkspace) updateSchema(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	id, obj, ok := f.findSchema(params[1])
+	if !ok {
+		writeFakeNotFound(w, "schemaKey")
+		return
+	}
+	previous := fakeFields(obj)
+
+	schema := map[string]interface{}{}
+	if !mergeFakeRequest(w, r, obj, &schema) {
+		return
+	}
+
+	if fakeString(schema, "schemaName") != fakeString(previous, "schemaName") {
+		if _, _, exists := f.findSchema(fakeString(schema, "schemaName")); exists {
+			writeFakeConflict(w, "Entity already exists.")
+			return
+		}
+	}
+	if !f.setSchemaFields(w, schema, previous) {
+		return
+	}
+	schema["schemaId"] = id
+
+	writeFakeLatest(w, f.put(fakeSchemas, id, schema))
+}
+
+func (f *fakeWorkspace) deleteSchema(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	id, _, ok := f.findSchema(params[1])
+	if !ok {
+		writeFakeNotFound(w, "schemaKey")
+		return
+	}
+
+	f.remove(fakeSchemas, id)
+	writeFakeNoContent(w)
+}
+
+func (f *fakeWorkspace) listSchemas(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"kind":    "admin#directory#schemas",
+		"schemas": f.list(fakeSchemas),
+	})
+}
+
+func (f *fakeWorkspace) getCustomer(w http.ResponseWriter, r *http.Request, params []string) {
+	if !f.checkCustomer(w, params[0]) {
+		return
+	}
+
+	obj, _ := f.get(fakeCustomers, fakeCustomerId)
+	writeFakeObject(w, r, obj)
+}
+
+// fakeFields decodes the latest version of the object.
+func fakeFields(obj *fakeObject) map[string]interface{} {
+	fields := map[string]interface{}{}
+	obj.latest(&fields)
+
+	return fields
+}
+
+func fakeString(fields map[string]interface{}, k string) string {
+	v, _ := fields[k].(string)
+	return v
+}
+
+func fakeStrings(fields map[string]interface{}, k string) []string {
+	var values []string
+	switch v := fields[k].(type) {
+	case []string:
+		values = v
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+
+	return values
+}
+
+// fakeDefaults sets the fields that aren't set in the request.
+func fakeDefaults(fields map[string]interface{}, defaults map[string]interface{}) {
+	for k, v := range defaults {
+		if _, ok := fields[k]; !ok {
+			fields[k] = v
+		}
+	}
+}
+
+func fakeRawItems(items []map[string]interface{}) []json.RawMessage {
+	var raw []json.RawMessage
+	for _, item := range items {
+		raw = append(raw, mustMarshalFake(item))
+	}
+
+	return raw
+}
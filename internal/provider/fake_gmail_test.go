@@ -0,0 +1,225 @@
+package googleworkspace
+
+import (
+	"net/http"
+	"strings"
+)
+
+const (
+	fakeGmailPath = "/gmail/v1/users/([^/]+)/settings/"
+
+	// send-as aliases are stored per user, by the lowercase email address of the
+	// user and alias
+	fakeSendAsPrefix = "sendas/"
+)
+
+func (f *fakeWorkspace) registerGmailRoutes() {
+	f.route(http.MethodPost, fakeGmailPath+"sendAs", f.createSendAs)
+	f.route(http.MethodGet, fakeGmailPath+"sendAs", f.listSendAs)
+	f.route(http.MethodGet, fakeGmailPath+"sendAs/([^/]+)", f.getSendAs)
+	f.route(http.MethodPut, fakeGmailPath+"sendAs/([^/]+)", f.updateSendAs)
+	f.route(http.MethodPatch, fakeGmailPath+"sendAs/([^/]+)", f.updateSendAs)
+	f.route(http.MethodDelete, fakeGmailPath+"sendAs/([^/]+)", f.deleteSendAs)
+}
+
+// gmailUser returns the user whose mailbox the request is for. Users can only
+// access their own mailbox.
+func (f *fakeWorkspace) gmailUser(w http.ResponseWriter, r *http.Request, userId string) (map[string]interface{}, bool) {
+	requestUser := fakeRequestUser(r)
+	if userId == "me" {
+		userId = requestUser
+	}
+
+	_, obj, ok := f.findByEmailOrId(fakeUsers, userId)
+	if !ok || userId == "" {
+		writeFakeError(w, http.StatusBadRequest, "Mail service not enabled", "failedPrecondition")
+		return nil, false
+	}
+
+	user := fakeFields(obj)
+	if !strings.EqualFold(fakeString(user, "primaryEmail"), requestUser) {
+		writeFakeError(w, http.StatusForbidden, "Delegation denied for "+requestUser, "forbidden")
+		return nil, false
+	}
+
+	return user, true
+}
+
+// primarySendAs is the send-as alias of the user's primary address, which is the
+// default unless another alias is.
+func (f *fakeWorkspace) primarySendAs(user map[string]interface{}) map[string]interface{} {
+	collection := fakeSendAsPrefix + strings.ToLower(fakeString(user, "primaryEmail"))
+
+	_, _, hasDefault := f.find(collection, func(obj *fakeObject) bool {
+		return fakeFields(obj)["isDefault"] == true
+	})
+
+	name, _ := user["name"].(map[string]interface{})
+	return map[string]interface{}{
+		"sendAsEmail":  user["primaryEmail"],
+		"displayName":  fakeString(name, "fullName"),
+		"isPrimary":    true,
+		"isDefault":    !hasDefault,
+		"treatAsAlias": false,
+	}
+}
+
+// setDefaultSendAs makes the alias the only default one.
+func (f *fakeWorkspace) setDefaultSendAs(collection, key string) {
+	for _, other := range f.keys(collection) {
+		if other == key {
+			continue
+		}
+
+		obj, _ := f.get(collection, other)
+		sendAs := fakeFields(obj)
+		if sendAs["isDefault"] == true {
+			sendAs["isDefault"] = false
+			f.put(collection, other, sendAs)
+		}
+	}
+}
+
+func (f *fakeWorkspace) createSendAs(w http.ResponseWriter, r *http.Request, params []string) {
+	user, ok := f.gmailUser(w, r, params[0])
+	if !ok {
+		return
+	}
+
+	sendAs := map[string]interface{}{}
+	if !decodeFakeRequest(w, r, &sendAs) {
+		return
+	}
+
+	email := fakeString(sendAs, "sendAsEmail")
+	key := strings.ToLower(email)
+	collection := fakeSendAsPrefix + strings.ToLower(fakeString(user, "primaryEmail"))
+
+	if email == "" {
+		writeFakeBadRequest(w, "Missing required field: sendAsEmail")
+		return
+	}
+	if _, exists := f.get(collection, key); exists || strings.EqualFold(email, fakeString(user, "primaryEmail")) {
+		writeFakeError(w, http.StatusConflict, "Duplicate alias: "+email, "alreadyExists")
+		return
+	}
+
+	// addresses outside the domain have to be verified by their owner
+	if f.isCustomerDomain(email) && f.emailInUse(email) {
+		sendAs["verificationStatus"] = "accepted"
+	} else {
+		sendAs["verificationStatus"] = "pending"
+		sendAs["isDefault"] = false
+	}
+	sendAs["isPrimary"] = false
+
+	if smtpMsa, ok := sendAs["smtpMsa"].(map[string]interface{}); ok {
+		delete(smtpMsa, "password")
+	}
+
+	obj := f.put(collection, key, sendAs)
+	if sendAs["isDefault"] == true {
+		f.setDefaultSendAs(collection, key)
+	}
+
+	writeFakeLatest(w, obj)
+}
+
+func (f *fakeWorkspace) getSendAs(w http.ResponseWriter, r *http.Request, params []string) {
+	user, ok := f.gmailUser(w, r, params[0])
+	if !ok {
+		return
+	}
+
+	if strings.EqualFold(params[1], fakeString(user, "primaryEmail")) {
+		writeFakeJSON(w, http.StatusOK, f.primarySendAs(user))
+		return
+	}
+
+	obj, ok := f.get(fakeSendAsPrefix+strings.ToLower(fakeString(user, "primaryEmail")), strings.ToLower(params[1]))
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Requested entity was not found.", "notFound")
+		return
+	}
+
+	writeFakeObject(w, r, obj)
+}
+
+func (f *fakeWorkspace) updateSendAs(w http.ResponseWriter, r *http.Request, params []string) {
+	user, ok := f.gmailUser(w, r, params[0])
+	if !ok {
+		return
+	}
+
+	collection := fakeSendAsPrefix + strings.ToLower(fakeString(user, "primaryEmail"))
+	key := strings.ToLower(params[1])
+
+	obj, ok := f.get(collection, key)
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Requested entity was not found.", "notFound")
+		return
+	}
+	previous := fakeFields(obj)
+
+	// a full update replaces every field, rather than only those in the request
+	sendAs := map[string]interface{}{}
+	if r.Method == http.MethodPut {
+		if !decodeFakeRequest(w, r, &sendAs) {
+			return
+		}
+	} else if !mergeFakeRequest(w, r, obj, &sendAs) {
+		return
+	}
+
+	for _, k := range []string{"sendAsEmail", "isPrimary", "verificationStatus"} {
+		sendAs[k] = previous[k]
+	}
+	if sendAs["verificationStatus"] != "accepted" && sendAs["isDefault"] == true {
+		writeFakeBadRequest(w, "Send-as address must be verified to be the default")
+		return
+	}
+	if smtpMsa, ok := sendAs["smtpMsa"].(map[string]interface{}); ok {
+		delete(smtpMsa, "password")
+	}
+
+	obj = f.put(collection, key, sendAs)
+	if sendAs["isDefault"] == true {
+		f.setDefaultSendAs(collection, key)
+	}
+
+	writeFakeLatest(w, obj)
+}
+
+func (f *fakeWorkspace) deleteSendAs(w http.ResponseWriter, r *http.Request, params []string) {
+	user, ok := f.gmailUser(w, r, params[0])
+	if !ok {
+		return
+	}
+
+	collection := fakeSendAsPrefix + strings.ToLower(fakeString(user, "primaryEmail"))
+	key := strings.ToLower(params[1])
+
+	if _, ok := f.get(collection, key); !ok {
+		writeFakeError(w, http.StatusNotFound, "Requested entity was not found.", "notFound")
+		return
+	}
+
+	f.remove(collection, key)
+	writeFakeNoContent(w)
+}
+
+func (f *fakeWorkspace) listSendAs(w http.ResponseWriter, r *http.Request, params []string) {
+	user, ok := f.gmailUser(w, r, params[0])
+	if !ok {
+		return
+	}
+
+	sendAs := []interface{}{f.primarySendAs(user)}
+	for _, item := range f.list(fakeSendAsPrefix + strings.ToLower(fakeString(user, "primaryEmail"))) {
+		sendAs = append(sendAs, item)
+	}
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"sendAs": sendAs,
+	})
+}
@@ -0,0 +1,129 @@
+package googleworkspace
+
+import (
+	"net/http"
+	"strings"
+)
+
+const (
+	fakeGroupsSettingsPath = "/groups/v1/groups/"
+
+	// group settings are stored by the lowercase email address of the group
+	fakeGroupSettings = "groupsettings"
+)
+
+func (f *fakeWorkspace) registerGroupsSettingsRoutes() {
+	f.route(http.MethodGet, fakeGroupsSettingsPath+"([^/]+)", f.getGroupSettings)
+	f.route(http.MethodPut, fakeGroupsSettingsPath+"([^/]+)", f.updateGroupSettings)
+	f.route(http.MethodPatch, fakeGroupsSettingsPath+"([^/]+)", f.updateGroupSettings)
+}
+
+// defaultGroupSettings creates the settings of a new group. Booleans are
+// strings in the Groups Settings API.
+func (f *fakeWorkspace) defaultGroupSettings(group map[string]interface{}) {
+	f.put(fakeGroupSettings, strings.ToLower(fakeString(group, "email")), map[string]interface{}{
+		"kind":                               "groupsSettings#groups",
+		"email":                              group["email"],
+		"name":                               group["name"],
+		"description":                        group["description"],
+		"whoCanJoin":                         "CAN_REQUEST_TO_JOIN",
+		"whoCanViewMembership":               "ALL_MEMBERS_CAN_VIEW",
+		"whoCanViewGroup":                    "ALL_MEMBERS_CAN_VIEW",
+		"allowExternalMembers":               "false",
+		"whoCanPostMessage":                  "ALL_IN_DOMAIN_CAN_POST",
+		"allowWebPosting":                    "true",
+		"primaryLanguage":                    "",
+		"isArchived":                         "false",
+		"archiveOnly":                        "false",
+		"messageModerationLevel":             "MODERATE_NONE",
+		"spamModerationLevel":                "MODERATE",
+		"replyTo":                            "REPLY_TO_IGNORE",
+		"customReplyTo":                      "",
+		"includeCustomFooter":                "false",
+		"customFooterText":                   "",
+		"sendMessageDenyNotification":        "false",
+		"defaultMessageDenyNotificationText": "",
+		"membersCanPostAsTheGroup":           "false",
+		"includeInGlobalAddressList":         "true",
+		"whoCanLeaveGroup":                   "ALL_MEMBERS_CAN_LEAVE",
+		"whoCanContactOwner":                 "ANYONE_CAN_CONTACT",
+		"whoCanModerateMembers":              "OWNERS_AND_MANAGERS",
+		"whoCanModerateContent":              "OWNERS_AND_MANAGERS",
+		"whoCanAssistContent":                "NONE",
+		"customRolesEnabledForSettingsToBeMerged": "false",
+		"enableCollaborativeInbox":                "false",
+		"whoCanDiscoverGroup":                     "ALL_IN_DOMAIN_CAN_DISCOVER",
+	})
+}
+
+// syncGroupSettings applies a change to the group to its settings.
+func (f *fakeWorkspace) syncGroupSettings(oldEmail string, group map[string]interface{}) {
+	obj, ok := f.get(fakeGroupSettings, strings.ToLower(oldEmail))
+	if !ok {
+		return
+	}
+
+	settings := fakeFields(obj)
+	if settings["email"] == group["email"] && settings["name"] == group["name"] && settings["description"] == group["description"] {
+		return
+	}
+
+	settings["email"] = group["email"]
+	settings["name"] = group["name"]
+	settings["description"] = group["description"]
+
+	newEmail := strings.ToLower(fakeString(group, "email"))
+	if newEmail != strings.ToLower(oldEmail) {
+		f.collection(fakeGroupSettings)[newEmail] = obj
+		f.remove(fakeGroupSettings, strings.ToLower(oldEmail))
+	}
+	f.put(fakeGroupSettings, newEmail, settings)
+}
+
+func (f *fakeWorkspace) getGroupSettings(w http.ResponseWriter, r *http.Request, params []string) {
+	obj, ok := f.get(fakeGroupSettings, strings.ToLower(params[0]))
+	if !ok {
+		writeFakeNotFound(w, "groupUniqueId")
+		return
+	}
+
+	writeFakeObject(w, r, obj)
+}
+
+func (f *fakeWorkspace) updateGroupSettings(w http.ResponseWriter, r *http.Request, params []string) {
+	key := strings.ToLower(params[0])
+
+	obj, ok := f.get(fakeGroupSettings, key)
+	if !ok {
+		writeFakeNotFound(w, "groupUniqueId")
+		return
+	}
+	previous := fakeFields(obj)
+
+	settings := map[string]interface{}{}
+	if !mergeFakeRequest(w, r, obj, &settings) {
+		return
+	}
+
+	// the group can't be renamed here
+	settings["email"] = previous["email"]
+
+	if settings["whoCanPostMessage"] == "NONE_CAN_POST" && settings["archiveOnly"] != "true" {
+		writeFakeBadRequest(w, "Invalid Input: whoCanPostMessage")
+		return
+	}
+
+	obj = f.put(fakeGroupSettings, key, settings)
+
+	// the name and description are those of the group
+	if settings["name"] != previous["name"] || settings["description"] != previous["description"] {
+		if id, group, ok := f.findByEmailOrId(fakeGroups, key); ok {
+			fields := fakeFields(group)
+			fields["name"] = settings["name"]
+			fields["description"] = settings["description"]
+			f.put(fakeGroups, id, fields)
+		}
+	}
+
+	writeFakeLatest(w, obj)
+}
@@ -0,0 +1,252 @@
+package googleworkspace
+
+import "sort"
+
+// Role and role assignment ids are large integers, like those of the real API.
+const fakeRoleIdOffset = 13801188331880000
+
+const fakeAdminServiceId = "00haapch16h1ysv"
+
+type fakePrivilegeService struct {
+	serviceId   string
+	serviceName string
+	ouScopable  bool
+	// each privilege is listed with those it includes
+	privileges map[string][]string
+}
+
+// fakePrivilegeServices is the catalog of privileges of the fake, which like the
+// real catalog lists some privileges more than once.
+var fakePrivilegeServices = []fakePrivilegeService{
+	{
+		serviceId:   fakeAdminServiceId,
+		serviceName: "admin",
+		ouScopable:  true,
+		privileges: map[string][]string{
+			"USERS_ALL": {"USERS_CREATE", "USERS_RETRIEVE", "USERS_UPDATE", "USERS_UPDATE_CUSTOM_ATTRIBUTES", "USERS_ALIAS",
+				"USERS_DELETE", "USERS_MOVE", "USERS_RESET_PASSWORD", "USERS_FORCE_PASSWORD_CHANGE", "USERS_SUSPEND", "USERS_SECURITY"},
+			"ORGANIZATION_UNITS_ALL": {"ORGANIZATION_UNITS_CREATE", "ORGANIZATION_UNITS_RETRIEVE", "ORGANIZATION_UNITS_UPDATE",
+				"ORGANIZATION_UNITS_DELETE"},
+		},
+	},
+	{
+		serviceId:   fakeAdminServiceId,
+		serviceName: "admin",
+		privileges: map[string][]string{
+			"GROUPS_ALL":           {"GROUPS_CREATE", "GROUPS_RETRIEVE", "GROUPS_UPDATE", "GROUPS_DELETE", "USERS_RETRIEVE"},
+			"DOMAIN_SETTINGS":      {"DOMAIN_SETTINGS_READ", "DOMAIN_MANAGEMENT", "DOMAIN_ALIASES"},
+			"ROLE_MANAGEMENT":      {"ROLE_MANAGEMENT_READ", "ROLE_ASSIGNMENTS_CREATE", "ROLE_ASSIGNMENTS_DELETE"},
+			"SCHEMA_MANAGEMENT":    {"SCHEMA_MANAGEMENT_READ", "SCHEMA_CREATE", "SCHEMA_UPDATE", "SCHEMA_DELETE"},
+			"REPORTS":              {"REPORTS_AUDIT", "REPORTS_USAGE", "REPORTS_ACTIVITY"},
+			"SECURITY_SETTINGS":    {"SECURITY_CENTER_READ", "SECURITY_INVESTIGATION_TOOL", "SECURITY_HEALTH", "ALERT_CENTER", "ALERT_CENTER_MANAGE"},
+			"DATA_TRANSFER":        {"DATA_TRANSFER_READ", "DATA_TRANSFER_CREATE"},
+			"BILLING_MANAGEMENT":   {"SUBSCRIPTIONS_MANAGE", "LICENSE_MANAGEMENT"},
+			"SUPPORT":              {"SUPPORT_CASES"},
+			"MANAGE_USER_SETTINGS": nil,
+		},
+	},
+	{
+		serviceId:   "02afmg282jiquyg",
+		serviceName: "chrome_os",
+		ouScopable:  true,
+		privileges: map[string][]string{
+			"MANAGE_DEVICES":              {"CHROME_DEVICES_READ", "MANAGE_DEVICE_SETTINGS", "DEPROVISION_DEVICES", "MOVE_DEVICES"},
+			"MANAGE_CHROME_USER_SETTINGS": nil,
+			"MANAGE_CHROME_BROWSERS":      nil,
+			"MANAGE_CHROME_APPS":          nil,
+			"MANAGE_PRINTERS":             nil,
+		},
+	},
+	{
+		serviceId:   "03hv69ve4bjwe54",
+		serviceName: "mobile",
+		ouScopable:  true,
+		privileges: map[string][]string{
+			"MOBILE_DEVICES":         {"MOBILE_DEVICES_READ", "MOBILE_APPROVE", "MOBILE_BLOCK", "MOBILE_WIPE", "MOBILE_DELETE"},
+			"MANAGE_MOBILE_SETTINGS": nil,
+			"MANAGE_MOBILE_APPS":     nil,
+		},
+	},
+	{
+		serviceId:   "01ci93xb3tmzyin",
+		serviceName: "calendar",
+		privileges: map[string][]string{
+			"CALENDAR_SETTINGS": {"CALENDAR_RESOURCES", "CALENDAR_BUILDINGS"},
+		},
+	},
+	{
+		serviceId:   "00tyjcwt49hg4mf",
+		serviceName: "drive_and_docs",
+		privileges: map[string][]string{
+			"DRIVE_SETTINGS": {"DRIVE_SHARING", "DRIVE_DATA_EXPORT", "SHARED_DRIVES"},
+		},
+	},
+	{
+		serviceId:   "01x0gk371sq486y",
+		serviceName: "gmail",
+		privileges: map[string][]string{
+			"GMAIL_SETTINGS": {"ACCESS_EMAIL_LOGS", "EMAIL_QUARANTINE", "MANAGE_GMAIL_ROUTING"},
+		},
+	},
+	{
+		serviceId:   "01rvwp1q4axizdr",
+		serviceName: "groups",
+		privileges: map[string][]string{
+			"GROUPS_FOR_BUSINESS_SETTINGS": {"GROUPS_SETTINGS_READ", "GROUPS_SETTINGS_UPDATE"},
+		},
+	},
+	{
+		serviceId:   "03cqmetx3zt4wmj",
+		serviceName: "meet",
+		privileges: map[string][]string{
+			"MEET_SETTINGS": {"MEET_RECORDINGS", "MEET_HARDWARE"},
+		},
+	},
+	{
+		serviceId:   "039kk8xu49mji9t",
+		serviceName: "sites",
+		privileges: map[string][]string{
+			"SITES_SETTINGS": {"SITES_CREATE"},
+		},
+	},
+	{
+		serviceId:   "04f1mdlm0ki64aw",
+		serviceName: "vault",
+		privileges: map[string][]string{
+			"VAULT_MATTERS": {"VAULT_MATTERS_READ", "VAULT_MATTERS_CREATE", "VAULT_HOLDS", "VAULT_SEARCH", "VAULT_EXPORT", "VAULT_AUDIT"},
+		},
+	},
+	{
+		serviceId:   "02jxsxqh1yxcqmz",
+		serviceName: "classroom",
+		privileges: map[string][]string{
+			"CLASSROOM_SETTINGS": {"CLASSROOM_DATA_ACCESS", "CLASSROOM_TEACHERS"},
+		},
+	},
+	{
+		serviceId:   "01fob9te2rj6rw9",
+		serviceName: "apps_script",
+		privileges: map[string][]string{
+			"APPS_SCRIPT_SETTINGS": {"APPS_SCRIPT_PROJECTS"},
+		},
+	},
+	{
+		serviceId:   "03whwml44f3n4vr",
+		serviceName: "contacts",
+		privileges: map[string][]string{
+			"CONTACTS_SETTINGS": {"CONTACTS_SHARING", "CONTACTS_DELEGATION"},
+		},
+	},
+	{
+		serviceId:   "01baon6m1v6t8vn",
+		serviceName: "jamboard",
+		privileges: map[string][]string{
+			"JAMBOARD_SETTINGS": nil,
+			"JAMBOARD_DEVICES":  nil,
+		},
+	},
+}
+
+// fakePrivileges returns the catalog as the API lists it.
+func fakePrivileges() []interface{} {
+	privilege := func(service fakePrivilegeService, name string) map[string]interface{} {
+		return map[string]interface{}{
+			"kind":          "admin#directory#privilege",
+			"etag":          `"fake-privilege"`,
+			"serviceId":     service.serviceId,
+			"serviceName":   service.serviceName,
+			"privilegeName": name,
+			"isOuScopable":  service.ouScopable,
+		}
+	}
+
+	items := []interface{}{}
+	for _, service := range fakePrivilegeServices {
+		for _, name := range sortedFakePrivilegeNames(service.privileges) {
+			item := privilege(service, name)
+
+			var children []interface{}
+			for _, child := range service.privileges[name] {
+				children = append(children, privilege(service, child))
+			}
+			if children != nil {
+				item["childPrivileges"] = children
+			}
+
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// fakePrivilegeCatalog returns whether each privilege in the catalog can be
+// scoped to an org unit, by fakePrivilegeKey.
+func fakePrivilegeCatalog() map[string]bool {
+	catalog := map[string]bool{}
+	for _, service := range fakePrivilegeServices {
+		for name, children := range service.privileges {
+			for _, privilege := range append([]string{name}, children...) {
+				key := fakePrivilegeKey(service.serviceId, privilege)
+				catalog[key] = catalog[key] || service.ouScopable
+			}
+		}
+	}
+
+	return catalog
+}
+
+func fakePrivilegeKey(serviceId, name string) string {
+	return serviceId + "/" + name
+}
+
+func sortedFakePrivilegeNames(privileges map[string][]string) []string {
+	var names []string
+	for name := range privileges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// fakeSystemRoles returns the roles every tenant has.
+func fakeSystemRoles() []map[string]interface{} {
+	rolePrivileges := func(names ...string) []interface{} {
+		var privileges []interface{}
+		for _, name := range names {
+			privileges = append(privileges, map[string]interface{}{
+				"serviceId":     fakeAdminServiceId,
+				"privilegeName": name,
+			})
+		}
+
+		return privileges
+	}
+
+	return []map[string]interface{}{
+		{
+			"roleName":         "_SEED_ADMIN_ROLE",
+			"roleDescription":  "Google Apps Administrator Seed Role",
+			"isSuperAdminRole": true,
+			"rolePrivileges": rolePrivileges("USERS_ALL", "ORGANIZATION_UNITS_ALL", "GROUPS_ALL", "DOMAIN_SETTINGS",
+				"ROLE_MANAGEMENT", "SCHEMA_MANAGEMENT", "REPORTS", "SECURITY_SETTINGS", "DATA_TRANSFER", "BILLING_MANAGEMENT"),
+		},
+		{
+			"roleName":        "_GROUPS_ADMIN_ROLE",
+			"roleDescription": "Groups Administrator",
+			"rolePrivileges": rolePrivileges("GROUPS_ALL", "GROUPS_CREATE", "GROUPS_RETRIEVE", "GROUPS_UPDATE", "GROUPS_DELETE",
+				"USERS_RETRIEVE"),
+		},
+		{
+			"roleName":        "_USER_MANAGEMENT_ADMIN_ROLE",
+			"roleDescription": "User Management Administrator",
+			"rolePrivileges":  rolePrivileges("USERS_ALL", "ORGANIZATION_UNITS_RETRIEVE"),
+		},
+		{
+			"roleName":        "_HELP_DESK_ADMIN_ROLE",
+			"roleDescription": "Help Desk Administrator",
+			"rolePrivileges":  rolePrivileges("USERS_RETRIEVE", "USERS_RESET_PASSWORD", "ORGANIZATION_UNITS_RETRIEVE"),
+		},
+	}
+}
@@ -0,0 +1,1161 @@
+package googleworkspace
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	directory "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/chromepolicy/v1"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/googleapi"
+)
+
+const (
+	// fakeWorkspaceEnvVar runs the acceptance tests and sweepers against an
+	// in-memory fake of the Workspace APIs, rather than a real tenant.
+	fakeWorkspaceEnvVar = "GOOGLEWORKSPACE_TEST_FAKE"
+
+	fakeCustomerId  = "C01fake00"
+	fakeDomain      = "example.com"
+	fakeAdminEmail  = "admin@" + fakeDomain
+	fakeAccessToken = "fake-access-token"
+)
+
+// fakeWorkspace is an in-memory fake of the Directory, Groups Settings, Gmail
+// send-as and Chrome Policy APIs used by the provider.
+//
+// Like the real APIs, every change to an object gets a new etag, and reads are
+// eventually consistent: each read of an object reveals at most one more of the
+// changes made to it, so a read straight after a change can return the object
+// as it was before.
+type fakeWorkspace struct {
+	server *httptest.Server
+
+	mutex   sync.Mutex
+	nextId  int64
+	objects map[string]map[string]*fakeObject
+	routes  []fakeRoute
+}
+
+// fakeObject is every version of an object in a collection, along with how many
+// of them have been revealed to reads.
+type fakeObject struct {
+	created  int64
+	versions []fakeVersion
+	visible  int
+}
+
+type fakeVersion struct {
+	etag string
+	body []byte
+}
+
+type fakeRoute struct {
+	method  string
+	pattern *regexp.Regexp
+	handler func(w http.ResponseWriter, r *http.Request, params []string)
+}
+
+func newFakeWorkspace() *fakeWorkspace {
+	f := &fakeWorkspace{
+		objects: map[string]map[string]*fakeObject{},
+	}
+
+	f.registerDirectoryRoutes()
+	f.registerGroupsSettingsRoutes()
+	f.registerGmailRoutes()
+	f.registerChromePolicyRoutes()
+
+	f.seedDirectory()
+	f.seedChromePolicy()
+
+	f.server = httptest.NewServer(f)
+
+	return f
+}
+
+func (f *fakeWorkspace) Close() {
+	f.server.Close()
+}
+
+// route registers the handler for requests matching the method and path
+// pattern, which is anchored and matched against the unescaped path.
+func (f *fakeWorkspace) route(method, pattern string, handler func(w http.ResponseWriter, r *http.Request, params []string)) {
+	f.routes = append(f.routes, fakeRoute{
+		method:  method,
+		pattern: regexp.MustCompile("^" + pattern + "$"),
+		handler: handler,
+	})
+}
+
+func (f *fakeWorkspace) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/token" {
+		f.token(w, r)
+		return
+	}
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer "+fakeAccessToken) {
+		writeFakeError(w, http.StatusUnauthorized, "Request had invalid authentication credentials.", "authError")
+		return
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if r.Method == http.MethodPost && r.URL.Path == "/"+directoryBatchApiPath {
+		f.batch(w, r)
+		return
+	}
+
+	f.dispatch(w, r)
+}
+
+func (f *fakeWorkspace) dispatch(w http.ResponseWriter, r *http.Request) {
+	for _, route := range f.routes {
+		if route.method != r.Method {
+			continue
+		}
+
+		if params := route.pattern.FindStringSubmatch(r.URL.Path); params != nil {
+			route.handler(w, r, params[1:])
+			return
+		}
+	}
+
+	writeFakeError(w, http.StatusNotFound, fmt.Sprintf("The requested URL %s was not found on this server.", r.URL.Path), "notFound")
+}
+
+// token exchanges any signed JWT for an access token, which identifies the
+// user impersonated by the JWT as `me`.
+func (f *fakeWorkspace) token(w http.ResponseWriter, r *http.Request) {
+	var claims struct {
+		Sub string `json:"sub"`
+	}
+
+	err := r.ParseForm()
+	if err == nil {
+		parts := strings.Split(r.PostForm.Get("assertion"), ".")
+		if len(parts) != 3 {
+			err = fmt.Errorf("the assertion is not a JWT")
+		} else {
+			var payload []byte
+			payload, err = base64.RawURLEncoding.DecodeString(parts[1])
+			if err == nil {
+				err = json.Unmarshal(payload, &claims)
+			}
+		}
+	}
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "invalid_grant", "error_description": "Invalid JWT Signature."}`))
+		return
+	}
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": fakeAccessToken + "." + base64.RawURLEncoding.EncodeToString([]byte(claims.Sub)),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+	})
+}
+
+// fakeRequestUser returns the email address of the user the request is made
+// as, if any.
+func fakeRequestUser(r *http.Request) string {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "+fakeAccessToken+".")
+
+	sub, _ := base64.RawURLEncoding.DecodeString(token)
+	return string(sub)
+}
+
+// batch serves a Directory API batch request by dispatching each part of it.
+func (f *fakeWorkspace) batch(w http.ResponseWriter, r *http.Request) {
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || params["boundary"] == "" {
+		writeFakeError(w, http.StatusBadRequest, "Invalid batch request.", "badRequest")
+		return
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	reader := multipart.NewReader(r.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+
+		req, err := http.ReadRequest(bufio.NewReader(part))
+		if err != nil {
+			writeFakeError(w, http.StatusBadRequest, "Invalid batch request part.", "badRequest")
+			return
+		}
+		req.Header.Set("Authorization", r.Header.Get("Authorization"))
+
+		rec := httptest.NewRecorder()
+		f.dispatch(rec, req)
+
+		partWriter, _ := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type": {"application/http"},
+			"Content-Id":   {"response-" + strings.Trim(part.Header.Get("Content-ID"), "<>")},
+		})
+		rec.Result().Write(partWriter)
+	}
+	writer.Close()
+
+	w.Header().Set("Content-Type", "multipart/mixed; boundary="+writer.Boundary())
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+}
+
+func (f *fakeWorkspace) newId() int64 {
+	f.nextId++
+	return f.nextId
+}
+
+func (f *fakeWorkspace) newEtag() string {
+	return fmt.Sprintf(`"fake-etag-%d"`, f.newId())
+}
+
+func (f *fakeWorkspace) collection(name string) map[string]*fakeObject {
+	if _, ok := f.objects[name]; !ok {
+		f.objects[name] = map[string]*fakeObject{}
+	}
+
+	return f.objects[name]
+}
+
+// put stores a new version of the object. A new object isn't visible to reads
+// until it's read for the first time.
+func (f *fakeWorkspace) put(collection, key string, v interface{}) *fakeObject {
+	etag := f.newEtag()
+
+	body := mustMarshalFake(v)
+	fields := map[string]interface{}{}
+	json.Unmarshal(body, &fields)
+	fields["etag"] = etag
+
+	obj, ok := f.collection(collection)[key]
+	if !ok {
+		obj = &fakeObject{created: f.newId(), visible: -1}
+		f.collection(collection)[key] = obj
+	}
+
+	obj.versions = append(obj.versions, fakeVersion{
+		etag: etag,
+		body: mustMarshalFake(fields),
+	})
+
+	return obj
+}
+
+// seed stores an object that is already consistent.
+func (f *fakeWorkspace) seed(collection, key string, v interface{}) {
+	obj := f.put(collection, key, v)
+	obj.visible = len(obj.versions) - 1
+}
+
+func (f *fakeWorkspace) get(collection, key string) (*fakeObject, bool) {
+	obj, ok := f.collection(collection)[key]
+	return obj, ok
+}
+
+func (f *fakeWorkspace) remove(collection, key string) {
+	delete(f.collection(collection), key)
+}
+
+// find returns the key and object of the first object in the collection that
+// matches.
+func (f *fakeWorkspace) find(collection string, match func(obj *fakeObject) bool) (string, *fakeObject, bool) {
+	for _, key := range f.keys(collection) {
+		obj := f.collection(collection)[key]
+		if match(obj) {
+			return key, obj, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// keys returns the keys of the collection in the order the objects were
+// created, which is the order they're listed in.
+func (f *fakeWorkspace) keys(collection string) []string {
+	var keys []string
+	for key := range f.collection(collection) {
+		keys = append(keys, key)
+	}
+
+	objects := f.collection(collection)
+	sort.Slice(keys, func(i, j int) bool {
+		return objects[keys[i]].created < objects[keys[j]].created
+	})
+
+	return keys
+}
+
+// list decodes the latest version of every object in the collection, as lists
+// are consistent.
+func (f *fakeWorkspace) list(collection string) []json.RawMessage {
+	var items []json.RawMessage
+	for _, key := range f.keys(collection) {
+		items = append(items, f.collection(collection)[key].latestBody())
+	}
+
+	return items
+}
+
+func (o *fakeObject) latestBody() []byte {
+	return o.versions[len(o.versions)-1].body
+}
+
+func (o *fakeObject) latest(v interface{}) {
+	if err := json.Unmarshal(o.latestBody(), v); err != nil {
+		panic(err)
+	}
+}
+
+// read reveals one more version of the object and returns it.
+func (o *fakeObject) read() fakeVersion {
+	if o.visible < len(o.versions)-1 {
+		o.visible++
+	}
+
+	return o.versions[o.visible]
+}
+
+// writeFakeObject serves a read of the object, honouring If-None-Match.
+func writeFakeObject(w http.ResponseWriter, r *http.Request, obj *fakeObject) {
+	writeFakeObjectWith(w, r, obj, nil)
+}
+
+// writeFakeObjectWith serves a read of the object, with any fields that don't
+// change its etag set by decorate.
+func writeFakeObjectWith(w http.ResponseWriter, r *http.Request, obj *fakeObject, decorate func(fields map[string]interface{})) {
+	version := obj.read()
+
+	w.Header().Set("Etag", version.etag)
+	if match := r.Header.Get("If-None-Match"); match != "" && match == version.etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	body := version.body
+	if decorate != nil {
+		fields := map[string]interface{}{}
+		json.Unmarshal(body, &fields)
+		decorate(fields)
+		body = mustMarshalFake(fields)
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// writeFakeLatest responds with the latest version of the object, as the
+// response to a change does.
+func writeFakeLatest(w http.ResponseWriter, obj *fakeObject) {
+	version := obj.versions[len(obj.versions)-1]
+
+	w.Header().Set("Etag", version.etag)
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(version.body)
+}
+
+func writeFakeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+	w.Write(mustMarshalFake(v))
+}
+
+func writeFakeNoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeFakeError responds with an error in the format of the Google APIs.
+func writeFakeError(w http.ResponseWriter, status int, message, reason string) {
+	writeFakeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    status,
+			"message": message,
+			"errors": []map[string]interface{}{
+				{
+					"domain":  "global",
+					"reason":  reason,
+					"message": message,
+				},
+			},
+		},
+	})
+}
+
+func writeFakeNotFound(w http.ResponseWriter, resource string) {
+	writeFakeError(w, http.StatusNotFound, fmt.Sprintf("Resource Not Found: %s", resource), "notFound")
+}
+
+func writeFakeConflict(w http.ResponseWriter, message string) {
+	writeFakeError(w, http.StatusConflict, message, "duplicate")
+}
+
+func writeFakeBadRequest(w http.ResponseWriter, message string) {
+	writeFakeError(w, http.StatusBadRequest, message, "invalid")
+}
+
+// decodeFakeRequest decodes the request body into v, responding with an error
+// if it can't be decoded.
+func decodeFakeRequest(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	body, err := ioutil.ReadAll(r.Body)
+	if err == nil {
+		err = json.Unmarshal(body, v)
+	}
+	if err != nil {
+		writeFakeBadRequest(w, fmt.Sprintf("Invalid JSON payload received. %s", err))
+		return false
+	}
+
+	return true
+}
+
+// mergeFakeRequest applies the fields set in the request body to the latest
+// version of the object, decoding the result into v.
+func mergeFakeRequest(w http.ResponseWriter, r *http.Request, obj *fakeObject, v interface{}) bool {
+	fields := map[string]interface{}{}
+	obj.latest(&fields)
+
+	var changes map[string]interface{}
+	if !decodeFakeRequest(w, r, &changes) {
+		return false
+	}
+	for k, change := range changes {
+		fields[k] = change
+	}
+
+	if err := json.Unmarshal(mustMarshalFake(fields), v); err != nil {
+		writeFakeBadRequest(w, fmt.Sprintf("Invalid value: %s", err))
+		return false
+	}
+
+	return true
+}
+
+// paginateFake returns the page of items requested by the maxResults (or
+// pageSize) and pageToken parameters, along with the token of the next page.
+func paginateFake(r *http.Request, items []json.RawMessage) ([]json.RawMessage, string) {
+	if items == nil {
+		items = []json.RawMessage{}
+	}
+
+	start, _ := strconv.Atoi(r.URL.Query().Get("pageToken"))
+	if start > len(items) {
+		start = len(items)
+	}
+
+	size, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+	if size == 0 {
+		size, _ = strconv.Atoi(r.URL.Query().Get("pageSize"))
+	}
+	if size <= 0 {
+		size = 100
+	}
+
+	end := start + size
+	if end >= len(items) {
+		return items[start:], ""
+	}
+
+	return items[start:end], strconv.Itoa(end)
+}
+
+func mustMarshalFake(v interface{}) []byte {
+	body, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	return body
+}
+
+// fakeCredentials returns service account credentials whose tokens are issued
+// by the fake.
+func (f *fakeWorkspace) fakeCredentials() (string, error) {
+	return testServiceAccountCredentials(f.server.URL + "/token")
+}
+
+// testServiceAccountCredentials returns the key of a service account that
+// doesn't exist, whose tokens are issued by tokenURI.
+func testServiceAccountCredentials(tokenURI string) (string, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return "", err
+	}
+
+	creds, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"project_id":   "fake-project",
+		"client_email": "fake@fake-project.iam.gserviceaccount.com",
+		"client_id":    "1234567890",
+		"private_key": string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		})),
+		"token_uri": tokenURI,
+	})
+
+	return string(creds), err
+}
+
+// setTestEnv points every provider and test client created by the tests at
+// the fake, by setting the environment variables they're configured from.
+func (f *fakeWorkspace) setTestEnv() error {
+	creds, err := f.fakeCredentials()
+	if err != nil {
+		return fmt.Errorf("error generating fake credentials: %s", err)
+	}
+
+	for _, k := range credsEnvVars {
+		os.Unsetenv(k)
+	}
+
+	env := map[string]string{
+		"GOOGLEWORKSPACE_CREDENTIALS":                     creds,
+		"GOOGLEWORKSPACE_CUSTOMER_ID":                     fakeCustomerId,
+		"GOOGLEWORKSPACE_DOMAIN":                          fakeDomain,
+		"GOOGLEWORKSPACE_IMPERSONATED_USER_EMAIL":         fakeAdminEmail,
+		"GOOGLEWORKSPACE_TEST_GMAIL_USER":                 fakeAdminEmail,
+		"GOOGLEWORKSPACE_CHROME_POLICY_CUSTOM_ENDPOINT":   f.server.URL + "/",
+		"GOOGLEWORKSPACE_DIRECTORY_CUSTOM_ENDPOINT":       f.server.URL + "/",
+		"GOOGLEWORKSPACE_GMAIL_CUSTOM_ENDPOINT":           f.server.URL + "/",
+		"GOOGLEWORKSPACE_GROUPS_SETTINGS_CUSTOM_ENDPOINT": f.server.URL + "/groups/v1/groups/",
+	}
+	for k, v := range env {
+		if err := os.Setenv(k, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// startFakeWorkspace starts the fake and points the tests at it, if they're
+// configured to run against it.
+func startFakeWorkspace() {
+	if os.Getenv(fakeWorkspaceEnvVar) != "true" {
+		return
+	}
+
+	f := newFakeWorkspace()
+	if err := f.setTestEnv(); err != nil {
+		log.Fatalf("[ERROR] Failed to configure the tests to use the fake Workspace APIs: %s", err)
+	}
+
+	log.Printf("[INFO] Running against the fake Workspace APIs at %s", f.server.URL)
+}
+
+// testFakeApiClient returns a client of the fake, impersonating the user.
+func testFakeApiClient(t *testing.T, f *fakeWorkspace, user string, batching *batchingConfig) *apiClient {
+	creds, err := f.fakeCredentials()
+	if err != nil {
+		t.Fatalf("error generating fake credentials: %s", err)
+	}
+
+	client := &apiClient{
+		Batching:                     batching,
+		Credentials:                  creds,
+		Customer:                     fakeCustomerId,
+		ImpersonatedUserEmail:        user,
+		ChromePolicyCustomEndpoint:   f.server.URL + "/",
+		DirectoryCustomEndpoint:      f.server.URL + "/",
+		GmailCustomEndpoint:          f.server.URL + "/",
+		GroupsSettingsCustomEndpoint: f.server.URL + "/groups/v1/groups/",
+	}
+
+	if diags := client.loadAndValidate(context.Background()); diags.HasError() {
+		t.Fatalf("error loading client: %s", diags[0].Summary)
+	}
+
+	return client
+}
+
+func testFakeDirectoryService(t *testing.T, client *apiClient) *directory.Service {
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		t.Fatalf("error creating directory service: %s", diags[0].Summary)
+	}
+
+	return directoryService
+}
+
+func TestFakeWorkspace_readsAreEventuallyConsistent(t *testing.T) {
+	f := newFakeWorkspace()
+	defer f.Close()
+
+	users := testFakeDirectoryService(t, testFakeApiClient(t, f, fakeAdminEmail, nil)).Users
+
+	user, err := users.Insert(&directory.User{
+		PrimaryEmail: "tf-test-user@" + fakeDomain,
+		Password:     "password123",
+		Name:         &directory.UserName{GivenName: "Test", FamilyName: "User"},
+	}).Do()
+	if err != nil {
+		t.Fatalf("error inserting user: %s", err)
+	}
+	if user.Password != "" {
+		t.Errorf("expected the password not to be returned, got %q", user.Password)
+	}
+
+	for _, givenName := range []string{"First", "Second"} {
+		if _, err := users.Patch(user.Id, &directory.User{Name: &directory.UserName{GivenName: givenName}}).Do(); err != nil {
+			t.Fatalf("error patching user: %s", err)
+		}
+	}
+
+	// each read reveals one more change
+	var etag string
+	for _, expected := range []string{"Test", "First", "Second"} {
+		read, err := users.Get(user.Id).Do()
+		if err != nil {
+			t.Fatalf("error getting user: %s", err)
+		}
+		if read.Name.GivenName != expected {
+			t.Errorf("expected given name %q, got %q", expected, read.Name.GivenName)
+		}
+		etag = read.Etag
+	}
+
+	_, err = users.Get(user.PrimaryEmail).IfNoneMatch(etag).Do()
+	if !googleapi.IsNotModified(err) {
+		t.Errorf("expected the user not to be modified, got %v", err)
+	}
+
+	_, err = users.Get("missing@" + fakeDomain).Do()
+	if !isApiErrorWithCode(err, http.StatusNotFound) {
+		t.Errorf("expected a missing user not to be found, got %v", err)
+	}
+}
+
+// Resources that wait for their changes to be consistent take tens of seconds
+// to do so, so the resources read here are created through the API.
+func TestFakeWorkspace_resources(t *testing.T) {
+	f := newFakeWorkspace()
+	defer f.Close()
+
+	ctx := context.Background()
+	client := testFakeApiClient(t, f, fakeAdminEmail, nil)
+	directoryService := testFakeDirectoryService(t, client)
+
+	user, err := directoryService.Users.Insert(&directory.User{
+		PrimaryEmail: "tf-test-user@" + fakeDomain,
+		Password:     "password123",
+		Name:         &directory.UserName{GivenName: "Test", FamilyName: "User"},
+	}).Do()
+	if err != nil {
+		t.Fatalf("error inserting user: %s", err)
+	}
+
+	group, err := directoryService.Groups.Insert(&directory.Group{Email: "tf-test-group@" + fakeDomain, Name: "Test Group"}).Do()
+	if err != nil {
+		t.Fatalf("error inserting group: %s", err)
+	}
+
+	settings := schema.TestResourceDataRaw(t, resourceGroupSettings().Schema, map[string]interface{}{})
+	settings.SetId(group.Email)
+	if diags := resourceGroupSettingsRead(ctx, settings, client); diags.HasError() {
+		t.Fatalf("error reading group settings: %s", diags[0].Summary)
+	}
+	if settings.Get("who_can_view_membership").(string) != "ALL_MEMBERS_CAN_VIEW" || settings.Get("name").(string) != "Test Group" {
+		t.Errorf("expected the group settings to have their defaults, got %q %q", settings.Get("who_can_view_membership"), settings.Get("name"))
+	}
+
+	member := schema.TestResourceDataRaw(t, resourceGroupMember().Schema, map[string]interface{}{
+		"group_id": group.Id,
+		"email":    user.PrimaryEmail,
+	})
+	if diags := resourceGroupMemberCreate(ctx, member, client); diags.HasError() {
+		t.Fatalf("error creating group member: %s", diags[0].Summary)
+	}
+	if member.Get("type").(string) != "USER" || member.Get("role").(string) != "MEMBER" {
+		t.Errorf("expected a USER MEMBER, got %q %q", member.Get("type"), member.Get("role"))
+	}
+
+	if diags := resourceGroupMemberDelete(ctx, member, client); diags.HasError() {
+		t.Fatalf("error deleting group member: %s", diags[0].Summary)
+	}
+	if diags := resourceGroupMemberRead(ctx, member, client); diags.HasError() || member.Id() != "" {
+		t.Errorf("expected the deleted group member to be gone, got %q", member.Id())
+	}
+}
+
+func TestFakeWorkspace_privileges(t *testing.T) {
+	f := newFakeWorkspace()
+	defer f.Close()
+
+	privileges, err := testFakeDirectoryService(t, testFakeApiClient(t, f, fakeAdminEmail, nil)).Privileges.List("my_customer").Do()
+	if err != nil {
+		t.Fatalf("error listing privileges: %s", err)
+	}
+
+	items := flattenAndPrunePrivileges(privileges.Items, map[string]bool{})
+	if len(items) != 107 {
+		t.Errorf("expected 107 unique privileges, got %d", len(items))
+	}
+}
+
+func TestFakeWorkspace_gmailSendAs(t *testing.T) {
+	f := newFakeWorkspace()
+	defer f.Close()
+
+	client := testFakeApiClient(t, f, fakeAdminEmail, nil)
+	gmailService, diags := client.NewGmailService(context.Background(), fakeAdminEmail)
+	if diags.HasError() {
+		t.Fatalf("error creating gmail service: %s", diags[0].Summary)
+	}
+
+	sendAs, err := gmailService.Users.Settings.SendAs.Create("me", &gmail.SendAs{
+		SendAsEmail: "tf-test-alias@example.net",
+		DisplayName: "Alias",
+		IsDefault:   true,
+	}).Do()
+	if err != nil {
+		t.Fatalf("error creating send-as alias: %s", err)
+	}
+	if sendAs.VerificationStatus != "pending" || sendAs.IsDefault {
+		t.Errorf("expected an unverified alias not to be the default, got %q %t", sendAs.VerificationStatus, sendAs.IsDefault)
+	}
+
+	primary, err := gmailService.Users.Settings.SendAs.Get("me", fakeAdminEmail).Do()
+	if err != nil {
+		t.Fatalf("error getting primary send-as alias: %s", err)
+	}
+	if !primary.IsPrimary || !primary.IsDefault {
+		t.Errorf("expected the primary address to be the default, got %t %t", primary.IsPrimary, primary.IsDefault)
+	}
+
+	_, err = gmailService.Users.Settings.SendAs.List("someone@" + fakeDomain).Do()
+	if !isApiErrorWithCode(err, http.StatusBadRequest) {
+		t.Errorf("expected an unknown mailbox to fail, got %v", err)
+	}
+}
+
+func TestFakeWorkspace_chromePolicies(t *testing.T) {
+	f := newFakeWorkspace()
+	defer f.Close()
+
+	ctx := context.Background()
+	client := testFakeApiClient(t, f, fakeAdminEmail, nil)
+	orgUnits := testFakeDirectoryService(t, client).Orgunits
+
+	parent, err := orgUnits.Insert(fakeCustomerId, &directory.OrgUnit{Name: "tf-test-parent", ParentOrgUnitPath: "/"}).Do()
+	if err != nil {
+		t.Fatalf("error inserting org unit: %s", err)
+	}
+	child, err := orgUnits.Insert(fakeCustomerId, &directory.OrgUnit{Name: "tf-test-child", ParentOrgUnitId: parent.OrgUnitId}).Do()
+	if err != nil {
+		t.Fatalf("error inserting org unit: %s", err)
+	}
+
+	policy := schema.TestResourceDataRaw(t, resourceChromePolicy().Schema, map[string]interface{}{
+		"org_unit_id": parent.OrgUnitId,
+		"policies": []interface{}{
+			map[string]interface{}{
+				"schema_name": "chrome.users.MaxConnectionsPerProxy",
+				"schema_values": map[string]interface{}{
+					"maxConnectionsPerProxy": "34",
+				},
+			},
+		},
+	})
+	if diags := resourceChromePolicyCreate(ctx, policy, client); diags.HasError() {
+		t.Fatalf("error creating chrome policy: %s", diags[0].Summary)
+	}
+	if v := policy.Get("policies.0.schema_values.maxConnectionsPerProxy"); v != "34" {
+		t.Errorf("expected the policy value to be read back, got %v", v)
+	}
+
+	chromePolicyService, diags := client.NewChromePolicyService()
+	if diags.HasError() {
+		t.Fatalf("error creating chrome policy service: %s", diags[0].Summary)
+	}
+	resolve := func(orgUnitId string) []*chromepolicy.GoogleChromePolicyV1ResolvedPolicy {
+		resp, err := chromePolicyService.Customers.Policies.Resolve("customers/"+fakeCustomerId, &chromepolicy.GoogleChromePolicyV1ResolveRequest{
+			PolicySchemaFilter: "chrome.users.MaxConnectionsPerProxy",
+			PolicyTargetKey: &chromepolicy.GoogleChromePolicyV1PolicyTargetKey{
+				TargetResource: fakeOrgUnitTarget(orgUnitId),
+			},
+		}).Do()
+		if err != nil {
+			t.Fatalf("error resolving chrome policy: %s", err)
+		}
+
+		return resp.ResolvedPolicies
+	}
+
+	// the child inherits the value of its parent
+	resolved := resolve(child.OrgUnitId)
+	if len(resolved) != 1 || resolved[0].SourceKey.TargetResource != fakeOrgUnitTarget(parent.OrgUnitId) {
+		t.Fatalf("expected the child to inherit the policy of its parent, got %+v", resolved)
+	}
+
+	if diags := resourceChromePolicyDelete(ctx, policy, client); diags.HasError() {
+		t.Fatalf("error deleting chrome policy: %s", diags[0].Summary)
+	}
+	if resolved := resolve(child.OrgUnitId); len(resolved) != 0 {
+		t.Errorf("expected the policy to be unset, got %+v", resolved)
+	}
+
+	// values have to match the schema
+	_, err = chromePolicyService.Customers.Policies.Orgunits.BatchModify("customers/"+fakeCustomerId, &chromepolicy.GoogleChromePolicyV1BatchModifyOrgUnitPoliciesRequest{
+		Requests: []*chromepolicy.GoogleChromePolicyV1ModifyOrgUnitPolicyRequest{
+			{
+				PolicyTargetKey: &chromepolicy.GoogleChromePolicyV1PolicyTargetKey{TargetResource: fakeOrgUnitTarget(parent.OrgUnitId)},
+				PolicyValue: &chromepolicy.GoogleChromePolicyV1PolicyValue{
+					PolicySchema: "chrome.printers.AllowForUsers",
+					Value:        googleapi.RawMessage(`{"allowForUsers": true}`),
+				},
+				UpdateMask: "allowForUsers",
+			},
+		},
+	}).Do()
+	if !isApiErrorWithCode(err, http.StatusBadRequest) {
+		t.Errorf("expected a policy without its additional target keys to fail, got %v", err)
+	}
+}
+
+// Only the fields managed by a chrome policy value are read and reset, the
+// other fields of the policy are left as they are.
+func TestFakeWorkspace_chromePolicyValueUnmanagedField(t *testing.T) {
+	f := newFakeWorkspace()
+	defer f.Close()
+
+	ctx := context.Background()
+	client := testFakeApiClient(t, f, fakeAdminEmail, nil)
+	orgUnits := testFakeDirectoryService(t, client).Orgunits
+
+	orgUnit, err := orgUnits.Insert(fakeCustomerId, &directory.OrgUnit{Name: "tf-test-proxy", ParentOrgUnitPath: "/"}).Do()
+	if err != nil {
+		t.Fatalf("error inserting org unit: %s", err)
+	}
+
+	chromePolicyService, diags := client.NewChromePolicyService()
+	if diags.HasError() {
+		t.Fatalf("error creating chrome policy service: %s", diags[0].Summary)
+	}
+	policyTargetKey := &chromepolicy.GoogleChromePolicyV1PolicyTargetKey{TargetResource: fakeOrgUnitTarget(orgUnit.OrgUnitId)}
+	resolve := func() map[string]interface{} {
+		resp, err := chromePolicyService.Customers.Policies.Resolve("customers/"+fakeCustomerId, &chromepolicy.GoogleChromePolicyV1ResolveRequest{
+			PolicySchemaFilter: "chrome.users.ProxySettings",
+			PolicyTargetKey:    policyTargetKey,
+		}).Do()
+		if err != nil {
+			t.Fatalf("error resolving chrome policy: %s", err)
+		}
+		if len(resp.ResolvedPolicies) == 0 {
+			return nil
+		}
+
+		value := map[string]interface{}{}
+		if err := json.Unmarshal(resp.ResolvedPolicies[0].Value.Value, &value); err != nil {
+			t.Fatalf("error decoding chrome policy value: %s", err)
+		}
+		return value
+	}
+
+	// the field is set outside of terraform
+	_, err = chromePolicyService.Customers.Policies.Orgunits.BatchModify("customers/"+fakeCustomerId, &chromepolicy.GoogleChromePolicyV1BatchModifyOrgUnitPoliciesRequest{
+		Requests: []*chromepolicy.GoogleChromePolicyV1ModifyOrgUnitPolicyRequest{
+			{
+				PolicyTargetKey: policyTargetKey,
+				PolicyValue: &chromepolicy.GoogleChromePolicyV1PolicyValue{
+					PolicySchema: "chrome.users.ProxySettings",
+					Value:        googleapi.RawMessage(`{"proxyPacUrl": "https://pac.example.com/proxy.pac"}`),
+				},
+				UpdateMask: "proxyPacUrl",
+			},
+		},
+	}).Do()
+	if err != nil {
+		t.Fatalf("error modifying chrome policy: %s", err)
+	}
+
+	r := resourceChromePolicyValue()
+	config := map[string]interface{}{
+		"org_unit_id": orgUnit.OrgUnitId,
+		"schema_name": "chrome.users.ProxySettings",
+		"schema_values": map[string]interface{}{
+			"proxyMode":   `"PROXY_MODE_ENUM_FIXED_SERVERS"`,
+			"proxyServer": `"proxy.example.com:8080"`,
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := resourceChromePolicyValueCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("error creating chrome policy value: %s", diags[0].Summary)
+	}
+	if v := d.Get("schema_values").(map[string]interface{}); len(v) != 2 {
+		t.Errorf("expected only the managed fields to be read, got %v", v)
+	}
+
+	// removing a field from the config only resets that field
+	config["schema_values"] = map[string]interface{}{
+		"proxyMode": `"PROXY_MODE_ENUM_FIXED_SERVERS"`,
+	}
+	state := d.State()
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(config), client)
+	if err != nil {
+		t.Fatalf("error planning chrome policy value: %s", err)
+	}
+	state, diags = r.Apply(ctx, state, diff, client)
+	if diags.HasError() {
+		t.Fatalf("error updating chrome policy value: %s", diags[0].Summary)
+	}
+	if state.Attributes["schema_values.%"] != "1" {
+		t.Errorf("expected only the managed field to be read, got %v", state.Attributes)
+	}
+	expected := map[string]interface{}{
+		"proxyMode":   "PROXY_MODE_ENUM_FIXED_SERVERS",
+		"proxyPacUrl": "https://pac.example.com/proxy.pac",
+	}
+	if value := resolve(); !reflect.DeepEqual(value, expected) {
+		t.Errorf("expected policy value %v, got %v", expected, value)
+	}
+
+	if diags := resourceChromePolicyValueDelete(ctx, r.Data(state), client); diags.HasError() {
+		t.Fatalf("error deleting chrome policy value: %s", diags[0].Summary)
+	}
+	expected = map[string]interface{}{
+		"proxyPacUrl": "https://pac.example.com/proxy.pac",
+	}
+	if value := resolve(); !reflect.DeepEqual(value, expected) {
+		t.Errorf("expected only the managed fields to be reset, got %v", value)
+	}
+
+	// every field that's set is imported
+	d = r.Data(nil)
+	d.SetId(orgUnit.OrgUnitId + "/chrome.users.ProxySettings")
+	if _, err := resourceChromePolicyValueImport(ctx, d, client); err != nil {
+		t.Fatalf("error importing chrome policy value: %s", err)
+	}
+	if diags := resourceChromePolicyValueRead(ctx, d, client); diags.HasError() {
+		t.Fatalf("error reading chrome policy value: %s", diags[0].Summary)
+	}
+	if v := d.Get("schema_values.proxyPacUrl"); v != `"https://pac.example.com/proxy.pac"` {
+		t.Errorf("expected the field that's set to be imported, got %v", d.Get("schema_values"))
+	}
+
+	// once none of its fields are set, the policy is inherited
+	if diags := resourceChromePolicyValueDelete(ctx, d, client); diags.HasError() {
+		t.Fatalf("error deleting chrome policy value: %s", diags[0].Summary)
+	}
+	if value := resolve(); value != nil {
+		t.Errorf("expected the policy to be inherited, got %v", value)
+	}
+}
+
+func TestFakeWorkspace_chromeGroupPolicies(t *testing.T) {
+	f := newFakeWorkspace()
+	defer f.Close()
+
+	ctx := context.Background()
+	client := testFakeApiClient(t, f, fakeAdminEmail, nil)
+	groups := testFakeDirectoryService(t, client).Groups
+
+	pilot, err := groups.Insert(&directory.Group{Email: "tf-test-pilot@" + fakeDomain}).Do()
+	if err != nil {
+		t.Fatalf("error inserting group: %s", err)
+	}
+	blocked, err := groups.Insert(&directory.Group{Email: "tf-test-blocked@" + fakeDomain}).Do()
+	if err != nil {
+		t.Fatalf("error inserting group: %s", err)
+	}
+
+	appId := "chrome:aapbdbdomjkkjkaonfhkkikfgjllcleb"
+	groupPolicy := func(groupId, installType string) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, resourceChromeGroupPolicy().Schema, map[string]interface{}{
+			"group_id":               groupId,
+			"additional_target_keys": map[string]interface{}{"app_id": appId},
+			"policies": []interface{}{
+				map[string]interface{}{
+					"schema_name": "chrome.users.apps.InstallType",
+					"schema_values": map[string]interface{}{
+						"appInstallType": `"` + installType + `"`,
+					},
+				},
+			},
+		})
+		if diags := resourceChromeGroupPolicyCreate(ctx, d, client); diags.HasError() {
+			t.Fatalf("error creating chrome group policy: %s", diags[0].Summary)
+		}
+		if v := d.Get("policies.0.schema_values.appInstallType"); v != `"`+installType+`"` {
+			t.Errorf("expected the policy value to be read back, got %v", v)
+		}
+
+		return d
+	}
+
+	pilotPolicy := groupPolicy(pilot.Id, "APP_INSTALL_TYPE_ENUM_FORCED")
+	groupPolicy(blocked.Id, "APP_INSTALL_TYPE_ENUM_BLOCKED")
+
+	ordering := func(groupIds ...interface{}) (*schema.ResourceData, diag.Diagnostics) {
+		d := schema.TestResourceDataRaw(t, resourceChromeGroupPriorityOrdering().Schema, map[string]interface{}{
+			"policy_namespace":       "chrome.users.apps",
+			"additional_target_keys": map[string]interface{}{"app_id": appId},
+			"group_ids":              groupIds,
+		})
+
+		return d, resourceChromeGroupPriorityOrderingCreate(ctx, d, client)
+	}
+
+	d, diags := ordering(blocked.Id, pilot.Id)
+	if diags.HasError() {
+		t.Fatalf("error creating chrome group priority ordering: %s", diags[0].Summary)
+	}
+	if v := d.Get("group_ids"); !reflect.DeepEqual(v, []interface{}{blocked.Id, pilot.Id}) {
+		t.Errorf("expected the ordering to be read back, got %v", v)
+	}
+
+	// the ordering has every group with policies for the app
+	if _, diags := ordering(pilot.Id); !diags.HasError() {
+		t.Errorf("expected an ordering without every group to fail")
+	}
+
+	if diags := resourceChromeGroupPolicyDelete(ctx, pilotPolicy, client); diags.HasError() {
+		t.Fatalf("error deleting chrome group policy: %s", diags[0].Summary)
+	}
+	if diags := resourceChromeGroupPolicyRead(ctx, pilotPolicy, client); diags.HasError() {
+		t.Fatalf("error reading chrome group policy: %s", diags[0].Summary)
+	}
+	if v := pilotPolicy.Get("policies.0.schema_values").(map[string]interface{}); len(v) != 0 {
+		t.Errorf("expected the policy to be deleted, got %v", v)
+	}
+
+	if diags := resourceChromeGroupPriorityOrderingRead(ctx, d, client); diags.HasError() {
+		t.Fatalf("error reading chrome group priority ordering: %s", diags[0].Summary)
+	}
+	if v := d.Get("group_ids"); !reflect.DeepEqual(v, []interface{}{blocked.Id}) {
+		t.Errorf("expected the group without policies to leave the ordering, got %v", v)
+	}
+
+	// every policy of the group is imported
+	imported := resourceChromeGroupPolicy().Data(nil)
+	imported.SetId(blocked.Id + "/chrome.users.apps.*?app_id=" + url.QueryEscape(appId))
+	if _, err := resourceChromeGroupPolicyImport(ctx, imported, client); err != nil {
+		t.Fatalf("error importing chrome group policy: %s", err)
+	}
+	if v := imported.Get("policies.0.schema_values.appInstallType"); v != `"APP_INSTALL_TYPE_ENUM_BLOCKED"` {
+		t.Errorf("expected the policy to be imported, got %v", imported.Get("policies"))
+	}
+}
+
+func TestFakeWorkspace_groupMembersCustomer(t *testing.T) {
+	f := newFakeWorkspace()
+	defer f.Close()
+
+	ctx := context.Background()
+	client := testFakeApiClient(t, f, fakeAdminEmail, nil)
+
+	group, err := testFakeDirectoryService(t, client).Groups.Insert(&directory.Group{Email: "tf-test-group@" + fakeDomain}).Do()
+	if err != nil {
+		t.Fatalf("error inserting group: %s", err)
+	}
+
+	r := resourceGroupMembers()
+	config := map[string]interface{}{
+		"group_id": group.Id,
+		"members": []interface{}{
+			map[string]interface{}{
+				"email": fakeCustomerId,
+				"type":  "CUSTOMER",
+			},
+			map[string]interface{}{
+				"email": "tf-test-member@example.net",
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := resourceGroupMembersCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("error creating group members: %s", diags[0].Summary)
+	}
+
+	members := d.Get("members").(*schema.Set).List()
+	if len(members) != 2 {
+		t.Fatalf("expected 2 members, got %v", members)
+	}
+
+	// the customer member is read without an email address, so it's read as
+	// its ID for the plan to be empty
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), client)
+	if err != nil {
+		t.Fatalf("error planning group members: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no changes, got %v", diff.Attributes)
+	}
+}
+
+func TestFakeWorkspace_batch(t *testing.T) {
+	f := newFakeWorkspace()
+	defer f.Close()
+
+	directoryService := testFakeDirectoryService(t, testFakeApiClient(t, f, fakeAdminEmail, &batchingConfig{SendAfter: 50 * time.Millisecond}))
+
+	group, err := directoryService.Groups.Insert(&directory.Group{Email: "tf-test-group@" + fakeDomain}).Do()
+	if err != nil {
+		t.Fatalf("error inserting group: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			member, err := directoryService.Members.Insert(group.Id, &directory.Member{Email: fmt.Sprintf("tf-test-%d@example.net", i)}).Do()
+			if err != nil {
+				t.Errorf("error inserting member: %s", err)
+				return
+			}
+			if member.Id == "" || member.Type != "USER" {
+				t.Errorf("expected an external USER member with an id, got %+v", member)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	members, err := directoryService.Members.List(group.Id).Do()
+	if err != nil {
+		t.Fatalf("error listing members: %s", err)
+	}
+	if len(members.Members) != 5 {
+		t.Errorf("expected 5 members, got %d", len(members.Members))
+	}
+}
@@ -13,6 +13,9 @@ var testResourcePrefixes = []string{
 }
 
 func TestMain(m *testing.M) {
+	startFakeWorkspace()
+	startVcr()
+
 	resource.TestMain(m)
 }
 
@@ -0,0 +1,197 @@
+package googleworkspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const (
+	// returned by the token endpoint when domain-wide delegation doesn't grant
+	// any one of the requested scopes
+	unauthorizedClientError = "unauthorized_client"
+
+	directoryScopePrefix     = "https://www.googleapis.com/auth/admin.directory."
+	chromePolicyScope        = "https://www.googleapis.com/auth/chrome.management.policy"
+	gmailSettingsScopePrefix = "https://www.googleapis.com/auth/gmail.settings."
+	groupsSettingsScope      = "https://www.googleapis.com/auth/apps.groups.settings"
+)
+
+// preflightChecks verifies the credentials of the provider before any resource
+// uses them, so a misconfiguration is reported along with how to fix it, rather
+// than as an error from whichever resource happens to run first.
+func (c *apiClient) preflightChecks(ctx context.Context) diag.Diagnostics {
+	log.Printf("[INFO] Running preflight checks")
+
+	diags := c.checkScopes()
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, c.checkApis(ctx)...)
+}
+
+// checkScopes gets an access token for the configured scopes. If domain-wide
+// delegation doesn't grant all of them, it reports which are missing.
+func (c *apiClient) checkScopes() diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	err := c.tokenError(c.ClientScopes)
+	if err == nil {
+		return diags
+	}
+
+	if !strings.Contains(err.Error(), unauthorizedClientError) {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Preflight check failed to get an access token",
+			Detail:   err.Error(),
+		})
+	}
+
+	// The token endpoint doesn't say which scopes aren't granted, so each is
+	// requested on its own.
+	var missing []string
+	for _, scope := range c.ClientScopes {
+		scopeErr := c.tokenError([]string{scope})
+		if scopeErr != nil && strings.Contains(scopeErr.Error(), unauthorizedClientError) {
+			missing = append(missing, scope)
+		}
+	}
+
+	if len(missing) == 0 {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Domain-wide delegation is not authorized for the requested OAuth scopes",
+			Detail:   err.Error(),
+		})
+	}
+
+	return append(diags, diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Domain-wide delegation is missing %d of the requested OAuth scopes", len(missing)),
+		Detail: fmt.Sprintf("%s is not authorized to act as %q with these scopes:\n\n%s\n\n"+
+			"Add them to its domain-wide delegation in the Admin console, under Security > Access and data control > "+
+			"API controls > Manage Domain Wide Delegation, where they can be pasted as:\n\n%s\n\n"+
+			"Alternatively, remove them from oauth_scopes.",
+			c.delegatedClient(), c.ImpersonatedUserEmail, strings.Join(missing, "\n"), strings.Join(missing, ",")),
+	})
+}
+
+// tokenError returns the error getting an access token for the scopes, if any.
+func (c *apiClient) tokenError(scopes []string) error {
+	ts, diags := c.tokenSource(scopes)
+	if diags.HasError() {
+		return fmt.Errorf("%s", diags[0].Summary)
+	}
+
+	_, err := ts.Token()
+	return err
+}
+
+// delegatedClient describes the client granted domain-wide delegation, as it's
+// identified in the Admin console.
+func (c *apiClient) delegatedClient() string {
+	if c.ImpersonateServiceAccount != "" {
+		return fmt.Sprintf("The client ID of service account %q", c.ImpersonateServiceAccount)
+	}
+
+	contents, _, err := pathOrContents(c.Credentials)
+	if err == nil {
+		var creds struct {
+			ClientEmail string `json:"client_email"`
+			ClientID    string `json:"client_id"`
+		}
+		if err := json.Unmarshal([]byte(contents), &creds); err == nil && creds.ClientID != "" {
+			return fmt.Sprintf("Client ID %s (%s)", creds.ClientID, creds.ClientEmail)
+		}
+	}
+
+	return "The client ID of the service account"
+}
+
+// checkApis makes a cheap read request to each API the configured scopes grant
+// access to. Failures are warnings, as the impersonated user may deliberately
+// only have access to some of the APIs.
+func (c *apiClient) checkApis(ctx context.Context) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	apiWarning := func(api string, err error) diag.Diagnostic {
+		return diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Preflight check of the %s API failed", api),
+			Detail: fmt.Sprintf("%s\n\nResources using the %s API are likely to fail. Check that the API is enabled in the "+
+				"Google Cloud project of the credentials, and that %q has an admin role with access to it.",
+				err, api, c.ImpersonatedUserEmail),
+		}
+	}
+
+	var groupEmail string
+	if c.hasScope(directoryScopePrefix) {
+		directoryService, serviceDiags := c.NewDirectoryService()
+		if serviceDiags.HasError() {
+			return append(diags, serviceDiags...)
+		}
+
+		if _, err := directoryService.Users.List().Customer(c.Customer).MaxResults(1).Context(ctx).Do(); err != nil {
+			diags = append(diags, apiWarning("Admin SDK Directory", err))
+		}
+
+		// a group is needed to check the Groups Settings API
+		groups, err := directoryService.Groups.List().Customer(c.Customer).MaxResults(1).Context(ctx).Do()
+		if err == nil && len(groups.Groups) > 0 {
+			groupEmail = groups.Groups[0].Email
+		}
+	}
+
+	if c.hasScope(groupsSettingsScope) && groupEmail != "" {
+		groupsSettingsService, serviceDiags := c.NewGroupsSettingsService()
+		if serviceDiags.HasError() {
+			return append(diags, serviceDiags...)
+		}
+
+		if _, err := groupsSettingsService.Groups.Get(groupEmail).Context(ctx).Do(); err != nil {
+			diags = append(diags, apiWarning("Groups Settings", err))
+		}
+	}
+
+	if c.hasScope(chromePolicyScope) {
+		chromePolicyService, serviceDiags := c.NewChromePolicyService()
+		if serviceDiags.HasError() {
+			return append(diags, serviceDiags...)
+		}
+
+		if _, err := chromePolicyService.Customers.PolicySchemas.List(fmt.Sprintf("customers/%s", c.Customer)).PageSize(1).Context(ctx).Do(); err != nil {
+			diags = append(diags, apiWarning("Chrome Policy", err))
+		}
+	}
+
+	// gmail settings are only accessible to the user they belong to
+	if c.hasScope(gmailSettingsScopePrefix) && c.ImpersonatedUserEmail != "" {
+		gmailService, serviceDiags := c.NewGmailService(ctx, c.ImpersonatedUserEmail)
+		if serviceDiags.HasError() {
+			return append(diags, serviceDiags...)
+		}
+
+		if _, err := gmailService.Users.Settings.SendAs.List(c.ImpersonatedUserEmail).Context(ctx).Do(); err != nil {
+			diags = append(diags, apiWarning("Gmail", err))
+		}
+	}
+
+	return diags
+}
+
+// hasScope reports whether any configured scope starts with the prefix.
+func (c *apiClient) hasScope(prefix string) bool {
+	for _, scope := range c.ClientScopes {
+		if strings.HasPrefix(scope, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
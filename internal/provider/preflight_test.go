@@ -0,0 +1,158 @@
+package googleworkspace
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// testPreflightServer issues tokens for the granted scopes only, like domain-wide
+// delegation, and answers API requests with the given status.
+func testPreflightServer(t *testing.T, grantedScopes []string, apiStatus int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path != "/token" {
+			w.WriteHeader(apiStatus)
+			if apiStatus != http.StatusOK {
+				w.Write([]byte(`{"error": {"code": 403, "message": "Not Authorized to access this resource/api"}}`))
+				return
+			}
+			w.Write([]byte(`{}`))
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("error parsing token request: %s", err)
+		}
+
+		// the scopes are in the claims of the signed JWT
+		parts := strings.Split(r.PostForm.Get("assertion"), ".")
+		if len(parts) != 3 {
+			t.Fatalf("expected a JWT assertion, got %q", r.PostForm.Get("assertion"))
+		}
+		payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+		if err != nil {
+			t.Fatalf("error decoding JWT payload: %s", err)
+		}
+		var claims struct {
+			Scope string `json:"scope"`
+		}
+		if err := json.Unmarshal(payload, &claims); err != nil {
+			t.Fatalf("error decoding JWT claims: %s", err)
+		}
+
+		for _, scope := range strings.Split(claims.Scope, " ") {
+			if !stringInSlice(grantedScopes, scope) {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"error": "unauthorized_client", "error_description": "Client is unauthorized to retrieve access tokens using this method, or client not authorized for any of the scopes requested."}`))
+				return
+			}
+		}
+
+		w.Write([]byte(`{"access_token": "token", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+}
+
+func testPreflightConfig(t *testing.T, server *httptest.Server, scopes []string) *apiClient {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error generating key: %s", err)
+	}
+
+	creds, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "sa@my-project.iam.gserviceaccount.com",
+		"client_id":    "1234567890",
+		"private_key": string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		})),
+		"token_uri": server.URL + "/token",
+	})
+	if err != nil {
+		t.Fatalf("error encoding credentials: %s", err)
+	}
+
+	config := &apiClient{
+		ClientScopes:               scopes,
+		Credentials:                string(creds),
+		Customer:                   "my_customer",
+		ImpersonatedUserEmail:      "admin@example.com",
+		ChromePolicyCustomEndpoint: server.URL,
+		DirectoryCustomEndpoint:    server.URL,
+	}
+
+	if diags := config.loadAndValidate(context.Background()); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	return config
+}
+
+func TestPreflightChecks(t *testing.T) {
+	scopes := []string{directoryScopePrefix + "user", chromePolicyScope}
+
+	server := testPreflightServer(t, scopes, http.StatusOK)
+	defer server.Close()
+
+	diags := testPreflightConfig(t, server, scopes).preflightChecks(context.Background())
+	if len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, got %v", diags)
+	}
+}
+
+func TestPreflightChecks_missingScopes(t *testing.T) {
+	scopes := []string{directoryScopePrefix + "user", directoryScopePrefix + "group", chromePolicyScope}
+
+	server := testPreflightServer(t, scopes[:1], http.StatusOK)
+	defer server.Close()
+
+	diags := testPreflightConfig(t, server, scopes).preflightChecks(context.Background())
+	if len(diags) != 1 || diags[0].Severity != diag.Error {
+		t.Fatalf("expected a single error, got %v", diags)
+	}
+
+	if !strings.Contains(diags[0].Summary, "missing 2 of the requested OAuth scopes") {
+		t.Errorf("expected summary to count the missing scopes, got %q", diags[0].Summary)
+	}
+
+	expected := []string{
+		"Client ID 1234567890 (sa@my-project.iam.gserviceaccount.com)",
+		strings.Join(scopes[1:], ","),
+	}
+	for _, e := range expected {
+		if !strings.Contains(diags[0].Detail, e) {
+			t.Errorf("expected detail to contain %q, got %q", e, diags[0].Detail)
+		}
+	}
+	if strings.Contains(diags[0].Detail, scopes[0]) {
+		t.Errorf("expected detail not to contain granted scope %q, got %q", scopes[0], diags[0].Detail)
+	}
+}
+
+func TestPreflightChecks_apiNotAuthorized(t *testing.T) {
+	scopes := []string{directoryScopePrefix + "user"}
+
+	server := testPreflightServer(t, scopes, http.StatusForbidden)
+	defer server.Close()
+
+	diags := testPreflightConfig(t, server, scopes).preflightChecks(context.Background())
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+
+	if !strings.Contains(diags[0].Summary, "Admin SDK Directory") {
+		t.Errorf("expected summary to name the API, got %q", diags[0].Summary)
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/mitchellh/go-homedir"
 
 	googleoauth "golang.org/x/oauth2/google"
@@ -51,8 +53,8 @@ func New(version string) func() *schema.Provider {
 			Schema: map[string]*schema.Schema{
 				"credentials": {
 					Description: "Either the path to or the contents of a service account key file in JSON format " +
-						"you can manage key files using the Cloud Console).  If not provided, the application default " +
-						"credentials will be used.",
+						"you can manage key files using the Cloud Console), or of an external account (workload identity " +
+						"federation) configuration file. If not provided, the application default credentials will be used.",
 					Type:     schema.TypeString,
 					Optional: true,
 					DefaultFunc: schema.MultiEnvDefaultFunc([]string{
@@ -73,6 +75,17 @@ func New(version string) func() *schema.Provider {
 					Optional: true,
 				},
 
+				"impersonate_service_account": {
+					Description: "The email of a service account to impersonate. The domain-wide delegation JWT is signed " +
+						"by the IAM Credentials API using the ambient credentials, which must be granted " +
+						"`roles/iam.serviceAccountTokenCreator` on this service account, so no service account key is required.",
+					Type: schema.TypeString,
+					DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+						"GOOGLEWORKSPACE_IMPERSONATE_SERVICE_ACCOUNT",
+					}, nil),
+					Optional: true,
+				},
+
 				"impersonated_user_email": {
 					Description: "The impersonated user's email with access to the Admin APIs can access the Admin SDK Directory API.",
 					Type:        schema.TypeString,
@@ -89,6 +102,202 @@ func New(version string) func() *schema.Provider {
 					Optional: true,
 					Elem:     &schema.Schema{Type: schema.TypeString},
 				},
+
+				"retry": {
+					Description: "Configures how requests to the Google Workspace APIs are retried. Requests failing " +
+						"with a `429` or `503` status, or a `quotaExceeded` error, are always retried.",
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"max_elapsed_time": {
+								Description:      "The maximum time spent retrying a request, as a duration such as `90s` or `5m`.",
+								Type:             schema.TypeString,
+								Optional:         true,
+								Default:          "1m",
+								ValidateDiagFunc: validateDuration,
+							},
+							"initial_backoff": {
+								Description:      "The time to wait before the first retry. It doubles for each subsequent retry.",
+								Type:             schema.TypeString,
+								Optional:         true,
+								Default:          "1s",
+								ValidateDiagFunc: validateDuration,
+							},
+							"max_backoff": {
+								Description:      "The maximum time to wait between retries.",
+								Type:             schema.TypeString,
+								Optional:         true,
+								Default:          "30s",
+								ValidateDiagFunc: validateDuration,
+							},
+							"jitter": {
+								Description: "The fraction by which each backoff is randomized. For example, `0.5` waits " +
+									"between 50% and 150% of the backoff.",
+								Type:             schema.TypeFloat,
+								Optional:         true,
+								Default:          0.5,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.FloatBetween(0, 1)),
+							},
+							"retryable_status_codes": {
+								Description: "Additional HTTP status codes to retry, such as `500`.",
+								Type:        schema.TypeList,
+								Optional:    true,
+								Elem: &schema.Schema{
+									Type:             schema.TypeInt,
+									ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(400, 599)),
+								},
+							},
+							"retryable_error_reasons": {
+								Description: "Additional error reasons to retry, such as `backendError` or `userRateLimitExceeded`.",
+								Type:        schema.TypeList,
+								Optional:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
+
+				"audit_log_path": {
+					Description: "The path of a file to append a record of every request that changes the tenant to, " +
+						"such as creating a user or removing a group member. Sensitive fields, such as passwords, are redacted.",
+					Type: schema.TypeString,
+					DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+						"GOOGLEWORKSPACE_AUDIT_LOG_PATH",
+					}, nil),
+					Optional: true,
+				},
+
+				"audit_log_format": {
+					Description: "The format of the records in the audit log. Acceptable values are `json`, which writes " +
+						"each record as a JSON object on its own line, and `text`.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          auditLogFormatJSON,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(auditLogFormats, false)),
+				},
+
+				"batching": {
+					Description: "Configures the batching of group member and alias changes made to the Directory API. " +
+						"Changes made concurrently are combined into [batch requests](https://developers.google.com/admin-sdk/directory/v1/guides/batch), " +
+						"which speeds up managing large numbers of them.",
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"enable_batching": {
+								Description: "Whether changes are batched.",
+								Type:        schema.TypeBool,
+								Optional:    true,
+								Default:     true,
+							},
+							"send_after": {
+								Description: "How long changes are collected before a batch is sent, as a duration such as `500ms`. " +
+									"Longer durations combine more changes, at the cost of delaying each of them.",
+								Type:             schema.TypeString,
+								Optional:         true,
+								Default:          "500ms",
+								ValidateDiagFunc: validateDuration,
+							},
+						},
+					},
+				},
+
+				"preflight_checks": {
+					Description: "Whether the credentials are checked when the provider is configured. Missing OAuth " +
+						"scopes in the domain-wide delegation are reported as errors, listing the scopes to add in the Admin " +
+						"console, and APIs that can't be read are reported as warnings.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				"read_only": {
+					Description: "Whether the provider is prevented from changing the tenant. Any request that could " +
+						"change it fails, which makes it safe to plan with credentials that are able to.",
+					Type: schema.TypeBool,
+					DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+						"GOOGLEWORKSPACE_READ_ONLY",
+					}, false),
+					Optional: true,
+				},
+
+				"rate_limit": {
+					Description: "Limits the rate of requests made to an API, shared by all resources and data sources. " +
+						"Requests to APIs without a rate limit are not limited.",
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"api": {
+								Description: "The API to limit. Acceptable values are `chromepolicy`, `directory`, `gmail` " +
+									"and `groupssettings`.",
+								Type:             schema.TypeString,
+								Required:         true,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(rateLimitedApis, false)),
+							},
+							"requests_per_second": {
+								Description:      "The number of requests per second allowed on average.",
+								Type:             schema.TypeFloat,
+								Required:         true,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.FloatAtLeast(0.001)),
+							},
+							"burst": {
+								Description:      "The maximum number of requests allowed at once.",
+								Type:             schema.TypeInt,
+								Optional:         true,
+								Default:          1,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+							},
+						},
+					},
+				},
+
+				"chrome_policy_custom_endpoint": {
+					Description: "The base URL used for requests to the Chrome Policy API, in place of " +
+						"`https://chromepolicy.googleapis.com/`. Useful for routing requests through a proxy or gateway, or to a fake for testing.",
+					Type: schema.TypeString,
+					DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+						"GOOGLEWORKSPACE_CHROME_POLICY_CUSTOM_ENDPOINT",
+					}, nil),
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+				},
+
+				"directory_custom_endpoint": {
+					Description: "The base URL used for requests to the Admin SDK Directory API, in place of " +
+						"`https://admin.googleapis.com/`. Useful for routing requests through a proxy or gateway, or to a fake for testing.",
+					Type: schema.TypeString,
+					DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+						"GOOGLEWORKSPACE_DIRECTORY_CUSTOM_ENDPOINT",
+					}, nil),
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+				},
+
+				"gmail_custom_endpoint": {
+					Description: "The base URL used for requests to the Gmail API, in place of " +
+						"`https://gmail.googleapis.com/`. Useful for routing requests through a proxy or gateway, or to a fake for testing.",
+					Type: schema.TypeString,
+					DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+						"GOOGLEWORKSPACE_GMAIL_CUSTOM_ENDPOINT",
+					}, nil),
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+				},
+
+				"groups_settings_custom_endpoint": {
+					Description: "The base URL used for requests to the Groups Settings API, in place of " +
+						"`https://www.googleapis.com/groups/v1/groups/`. Useful for routing requests through a proxy or gateway, or to a fake for testing.",
+					Type: schema.TypeString,
+					DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+						"GOOGLEWORKSPACE_GROUPS_SETTINGS_CUSTOM_ENDPOINT",
+					}, nil),
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+				},
 			},
 			DataSourcesMap: map[string]*schema.Resource{
 				"googleworkspace_chrome_policy_schema": dataSourceChromePolicySchema(),
@@ -96,29 +305,45 @@ func New(version string) func() *schema.Provider {
 				"googleworkspace_domain_alias":         dataSourceDomainAlias(),
 				"googleworkspace_group":                dataSourceGroup(),
 				"googleworkspace_group_member":         dataSourceGroupMember(),
+				"googleworkspace_group_members":        dataSourceGroupMembers(),
 				"googleworkspace_group_settings":       dataSourceGroupSettings(),
+				"googleworkspace_groups":               dataSourceGroups(),
 				"googleworkspace_org_unit":             dataSourceOrgUnit(),
+				"googleworkspace_org_units":            dataSourceOrgUnits(),
 				"googleworkspace_privileges":           dataSourcePrivileges(),
 				"googleworkspace_role":                 dataSourceRole(),
+				"googleworkspace_role_assignments":     dataSourceRoleAssignments(),
+				"googleworkspace_roles":                dataSourceRoles(),
 				"googleworkspace_schema":               dataSourceSchema(),
 				"googleworkspace_user":                 dataSourceUser(),
+				"googleworkspace_users":                dataSourceUsers(),
 			},
 			ResourcesMap: map[string]*schema.Resource{
-				"googleworkspace_chrome_policy":       resourceChromePolicy(),
-				"googleworkspace_domain":              resourceDomain(),
-				"googleworkspace_domain_alias":        resourceDomainAlias(),
-				"googleworkspace_gmail_send_as_alias": resourceGmailSendAsAlias(),
-				"googleworkspace_group":               resourceGroup(),
-				"googleworkspace_group_member":        resourceGroupMember(),
-				"googleworkspace_group_settings":      resourceGroupSettings(),
-				"googleworkspace_org_unit":            resourceOrgUnit(),
-				"googleworkspace_role":                resourceRole(),
-				"googleworkspace_role_assignment":     resourceRoleAssignment(),
-				"googleworkspace_schema":              resourceSchema(),
-				"googleworkspace_user":                resourceUser(),
+				"googleworkspace_chrome_group_policy":            resourceChromeGroupPolicy(),
+				"googleworkspace_chrome_group_priority_ordering": resourceChromeGroupPriorityOrdering(),
+				"googleworkspace_chrome_policy":                  resourceChromePolicy(),
+				"googleworkspace_chrome_policy_value":            resourceChromePolicyValue(),
+				"googleworkspace_domain":                         resourceDomain(),
+				"googleworkspace_domain_alias":                   resourceDomainAlias(),
+				"googleworkspace_gmail_send_as_alias":            resourceGmailSendAsAlias(),
+				"googleworkspace_group":                          resourceGroup(),
+				"googleworkspace_group_alias":                    resourceGroupAlias(),
+				"googleworkspace_group_member":                   resourceGroupMember(),
+				"googleworkspace_group_members":                  resourceGroupMembers(),
+				"googleworkspace_group_settings":                 resourceGroupSettings(),
+				"googleworkspace_org_unit":                       resourceOrgUnit(),
+				"googleworkspace_role":                           resourceRole(),
+				"googleworkspace_role_assignment":                resourceRoleAssignment(),
+				"googleworkspace_schema":                         resourceSchema(),
+				"googleworkspace_user":                           resourceUser(),
+				"googleworkspace_user_alias":                     resourceUserAlias(),
 			},
 		}
 
+		for resourceType, r := range p.ResourcesMap {
+			withResourceInfo(resourceType, r)
+		}
+
 		p.ConfigureContextFunc = configure(version, p)
 
 		return p
@@ -147,6 +372,11 @@ func configure(version string, p *schema.Provider) func(context.Context, *schema
 			return nil, diags
 		}
 
+		// Get impersonated service account
+		if v, ok := d.GetOk("impersonate_service_account"); ok {
+			config.ImpersonateServiceAccount = v.(string)
+		}
+
 		// Get impersonated user email
 		if v, ok := d.GetOk("impersonated_user_email"); ok {
 			config.ImpersonatedUserEmail = v.(string)
@@ -161,9 +391,66 @@ func configure(version string, p *schema.Provider) func(context.Context, *schema
 			config.ClientScopes[i] = scope.(string)
 		}
 
+		// Get custom endpoints
+		if v, ok := d.GetOk("chrome_policy_custom_endpoint"); ok {
+			config.ChromePolicyCustomEndpoint = v.(string)
+		}
+
+		if v, ok := d.GetOk("directory_custom_endpoint"); ok {
+			config.DirectoryCustomEndpoint = v.(string)
+		}
+
+		if v, ok := d.GetOk("gmail_custom_endpoint"); ok {
+			config.GmailCustomEndpoint = v.(string)
+		}
+
+		if v, ok := d.GetOk("groups_settings_custom_endpoint"); ok {
+			config.GroupsSettingsCustomEndpoint = v.(string)
+		}
+
+		// Get rate limits
+		if v, ok := d.GetOk("rate_limit"); ok {
+			rateLimits, diags := expandRateLimits(v.([]interface{}))
+			if diags.HasError() {
+				return nil, diags
+			}
+			config.RateLimits = rateLimits
+		}
+
+		// Get retry policy
+		if v, ok := d.GetOk("retry"); ok {
+			config.RetryPolicy = expandRetryPolicy(v.([]interface{}))
+		}
+
+		// Get read only mode
+		if v, ok := d.GetOk("read_only"); ok {
+			config.ReadOnly = v.(bool)
+		}
+
+		// Get audit log
+		if v, ok := d.GetOk("audit_log_path"); ok {
+			config.AuditLogPath = v.(string)
+		}
+
+		if v, ok := d.GetOk("audit_log_format"); ok {
+			config.AuditLogFormat = v.(string)
+		}
+
+		// Get batching config
+		if v, ok := d.GetOk("batching"); ok {
+			config.Batching = expandBatchingConfig(v.([]interface{}))
+		}
+
 		config.UserAgent = p.UserAgent("terraform-provider-googleworkspace", version)
 
 		diags = config.loadAndValidate(ctx)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		if d.Get("preflight_checks").(bool) {
+			diags = append(diags, config.preflightChecks(ctx)...)
+		}
 
 		return &config, diags
 	}
@@ -194,3 +481,85 @@ func validateCredentials(v interface{}, p cty.Path) diag.Diagnostics {
 
 	return diags
 }
+
+func validateDuration(v interface{}, p cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("%q is not a valid duration: %s", v.(string), err),
+			AttributePath: p,
+		})
+	}
+
+	return diags
+}
+
+func expandRetryPolicy(v []interface{}) *retryPolicy {
+	policy := defaultRetryPolicy()
+	if len(v) == 0 || v[0] == nil {
+		return policy
+	}
+
+	retry := v[0].(map[string]interface{})
+
+	// durations have already been validated
+	policy.MaxElapsedTime, _ = time.ParseDuration(retry["max_elapsed_time"].(string))
+	policy.InitialBackoff, _ = time.ParseDuration(retry["initial_backoff"].(string))
+	policy.MaxBackoff, _ = time.ParseDuration(retry["max_backoff"].(string))
+	policy.Jitter = retry["jitter"].(float64)
+
+	for _, code := range retry["retryable_status_codes"].([]interface{}) {
+		policy.RetryableStatusCodes = append(policy.RetryableStatusCodes, code.(int))
+	}
+	policy.RetryableErrorReasons = listOfInterfacestoStrings(retry["retryable_error_reasons"])
+
+	return policy
+}
+
+func expandBatchingConfig(v []interface{}) *batchingConfig {
+	if len(v) == 0 {
+		return nil
+	}
+
+	// an empty block enables batching with the defaults
+	config := defaultBatchingConfig()
+	if v[0] == nil {
+		return config
+	}
+
+	batching := v[0].(map[string]interface{})
+	if !batching["enable_batching"].(bool) {
+		return nil
+	}
+
+	// the duration has already been validated
+	config.SendAfter, _ = time.ParseDuration(batching["send_after"].(string))
+
+	return config
+}
+
+func expandRateLimits(v []interface{}) (map[string]rateLimit, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	rateLimits := map[string]rateLimit{}
+
+	for _, rl := range v {
+		limit := rl.(map[string]interface{})
+		api := limit["api"].(string)
+
+		if _, ok := rateLimits[api]; ok {
+			return nil, append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("rate_limit is configured more than once for api %q", api),
+			})
+		}
+
+		rateLimits[api] = rateLimit{
+			RequestsPerSecond: limit["requests_per_second"].(float64),
+			Burst:             limit["burst"].(int),
+		}
+	}
+
+	return rateLimits, diags
+}
@@ -2,8 +2,12 @@ package googleworkspace
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -11,6 +15,7 @@ import (
 
 	"golang.org/x/oauth2"
 	googleoauth "golang.org/x/oauth2/google"
+	"golang.org/x/time/rate"
 	"google.golang.org/api/chromepolicy/v1"
 	"google.golang.org/api/gmail/v1"
 	"google.golang.org/api/option"
@@ -20,13 +25,39 @@ import (
 )
 
 type apiClient struct {
-	client *http.Client
-
-	ClientScopes          []string
-	Credentials           string
-	Customer              string
-	ImpersonatedUserEmail string
-	UserAgent             string
+	auditLogger *auditLogger
+	client      *http.Client
+	// sends every request, including token exchanges, before auth is added
+	baseClient   *http.Client
+	rateLimiters map[string]*rate.Limiter
+
+	// batches group member and alias mutations, shared by all Directory API requests
+	directoryBatcher *batchTransport
+
+	// API services are built on first use and reused by all resources
+	servicesMutex         sync.Mutex
+	chromePolicyService   *chromepolicy.Service
+	directoryService      *directory.Service
+	gmailServices         map[string]*gmail.Service
+	groupsSettingsService *groupssettings.Service
+
+	AuditLogFormat            string
+	AuditLogPath              string
+	Batching                  *batchingConfig
+	ClientScopes              []string
+	Credentials               string
+	Customer                  string
+	ImpersonateServiceAccount string
+	ImpersonatedUserEmail     string
+	RateLimits                map[string]rateLimit
+	ReadOnly                  bool
+	RetryPolicy               *retryPolicy
+	UserAgent                 string
+
+	ChromePolicyCustomEndpoint   string
+	DirectoryCustomEndpoint      string
+	GmailCustomEndpoint          string
+	GroupsSettingsCustomEndpoint string
 }
 
 func (c *apiClient) loadAndValidate(ctx context.Context) diag.Diagnostics {
@@ -36,42 +67,243 @@ func (c *apiClient) loadAndValidate(ctx context.Context) diag.Diagnostics {
 		c.ClientScopes = DefaultClientScopes
 	}
 
+	if c.RetryPolicy == nil {
+		c.RetryPolicy = defaultRetryPolicy()
+	}
+
+	if c.rateLimiters == nil {
+		c.rateLimiters = newRateLimiters(c.RateLimits)
+	}
+
+	if c.auditLogger == nil && c.AuditLogPath != "" {
+		auditLogger, err := newAuditLogger(c.AuditLogPath, c.AuditLogFormat)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		c.auditLogger = auditLogger
+	}
+
+	// As with the oauth2 package, an HTTP client in the context is used to send
+	// requests, which is how the tests record and replay them.
+	if c.baseClient == nil {
+		c.baseClient = cleanhttp.DefaultClient()
+		if client, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok {
+			c.baseClient = client
+		}
+	}
+
+	cleanCtx := c.tokenContext()
+
+	tokenSource, diags := c.tokenSource(c.ClientScopes)
+	if diags.HasError() {
+		return diags
+	}
+
+	// 1. OAUTH2 TRANSPORT/CLIENT - sets up proper auth headers
+	client := oauth2.NewClient(cleanCtx, tokenSource)
+
+	// 2. Logging Transport - ensure we log HTTP requests to admin APIs.
+	loggingTransport := logging.NewTransport("Google Workspace", client.Transport)
+
+	// Set final transport value. Rate limiting and retries are added per API,
+	// see apiHTTPClient.
+	client.Transport = loggingTransport
+
+	c.client = client
+
+	if c.Batching != nil {
+		c.directoryBatcher = newBatchTransport(loggingTransport, c.Batching)
+	}
+
+	return diags
+}
+
+// tokenSource returns the token source used to authenticate requests to the
+// admin APIs. If a service account is to be impersonated, tokens are minted by
+// signing the domain-wide delegation JWT through the IAM Credentials API,
+// otherwise they come straight from the loaded credentials.
+func (c *apiClient) tokenSource(scopes []string) (oauth2.TokenSource, diag.Diagnostics) {
+	if c.ImpersonateServiceAccount != "" {
+		log.Printf("[INFO] Impersonating service account %q", c.ImpersonateServiceAccount)
+
+		// The ambient credentials only need to call the IAM Credentials API, the
+		// admin scopes and subject are part of the JWT signed on their behalf.
+		creds, diags := c.loadCredentials(googleoauth.CredentialsParams{
+			Scopes: []string{cloudPlatformScope},
+		})
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// the JWT is signed on every token fetch, after configuration is done
+		ctx := c.tokenContext()
+		ts, err := newSignJwtTokenSource(ctx, oauth2.NewClient(ctx, creds.TokenSource), c.ImpersonateServiceAccount,
+			c.ImpersonatedUserEmail, scopes)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		return oauth2.ReuseTokenSource(nil, ts), diags
+	}
+
+	creds, diags := c.loadCredentials(googleoauth.CredentialsParams{
+		Scopes: scopes,
+		// Subject is only honored for service account keys, it is the user
+		// the service account acts as with domain-wide delegation.
+		Subject: c.ImpersonatedUserEmail,
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	credsType := credentialsType(creds.JSON)
+	log.Printf("[INFO] Using credentials of type %q", credsType)
+
+	if c.ImpersonatedUserEmail != "" && credsType != serviceAccountCredentialsType {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "impersonated_user_email requires service account credentials",
+			Detail: fmt.Sprintf("Domain-wide delegation signs a JWT with a service account key, but the "+
+				"credentials found are of type %q. Set impersonate_service_account to have the IAM "+
+				"Credentials API sign the JWT instead.", credsType),
+		})
+
+		return nil, diags
+	}
+
+	return creds.TokenSource, diags
+}
+
+// loadCredentials returns the credentials from the `credentials` argument if
+// it is set, otherwise it falls back to the application default credentials.
+// Both service account keys and external account (workload identity federation)
+// configurations are supported.
+func (c *apiClient) loadCredentials(params googleoauth.CredentialsParams) (*googleoauth.Credentials, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// external accounts exchange and impersonate tokens with this context
+	ctx := c.tokenContext()
+
+	var creds *googleoauth.Credentials
 	if c.Credentials != "" {
 		contents, _, err := pathOrContents(c.Credentials)
 		if err != nil {
-			return diag.FromErr(err)
+			return nil, diag.FromErr(err)
 		}
 
-		jwtConfig, err := googleoauth.JWTConfigFromJSON([]byte(contents), c.ClientScopes...)
+		creds, err = googleoauth.CredentialsFromJSONWithParams(ctx, []byte(contents), params)
 		if err != nil {
-			return diag.FromErr(err)
+			return nil, diag.FromErr(err)
 		}
+	} else {
+		log.Printf("[INFO] Authenticating using application default credentials")
 
-		jwtConfig.Subject = c.ImpersonatedUserEmail
+		var err error
+		creds, err = googleoauth.FindDefaultCredentialsWithParams(ctx, params)
+		if err != nil {
+			return nil, diag.Errorf("credentials were not provided and application default credentials "+
+				"could not be found: %s", err)
+		}
+	}
 
-		cleanCtx := context.WithValue(ctx, oauth2.HTTPClient, cleanhttp.DefaultClient())
+	return creds, diags
+}
 
-		// 1. OAUTH2 TRANSPORT/CLIENT - sets up proper auth headers
-		client := jwtConfig.Client(cleanCtx)
+// tokenContext returns the context that token sources send their requests
+// with. Tokens are fetched on first use, and refreshed, long after the context
+// the provider is configured with is canceled, so it only carries the HTTP
+// client that requests are sent with.
+func (c *apiClient) tokenContext() context.Context {
+	return context.WithValue(context.Background(), oauth2.HTTPClient, c.baseClient)
+}
 
-		// 2. Logging Transport - ensure we log HTTP requests to admin APIs.
-		loggingTransport := logging.NewTransport("Google Workspace", client.Transport)
+const serviceAccountCredentialsType = "service_account"
 
-		// Set final transport value.
-		client.Transport = loggingTransport
+// credentialsType returns the `type` field of JSON credentials. Credentials
+// without JSON, such as those from the GCE metadata server, return "compute".
+func credentialsType(contents []byte) string {
+	if len(contents) == 0 {
+		return "compute"
+	}
 
-		c.client = client
+	var f struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(contents, &f); err != nil {
+		return ""
 	}
 
-	return diags
+	return f.Type
+}
+
+// apiHTTPClient returns the HTTP client used for requests to the given API.
+// Each request waits for the API's rate limiter, if configured, and requests
+// that fail with retryable errors are retried according to the retry policy.
+func (c *apiClient) apiHTTPClient(api string) *http.Client {
+	transport := c.client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	// batched requests are rate limited and retried individually
+	if api == directoryApi && c.directoryBatcher != nil {
+		transport = c.directoryBatcher
+	}
+
+	if limiter, ok := c.rateLimiters[api]; ok {
+		transport = newRateLimitTransport(transport, limiter)
+	}
+
+	if c.RetryPolicy != nil {
+		transport = newRetryTransport(transport, c.RetryPolicy)
+	}
+
+	// audit the outcome of each request, not of each attempt
+	if c.auditLogger != nil {
+		transport = newAuditTransport(transport, c.auditLogger)
+	}
+
+	// blocked requests are never sent, so they aren't audited either
+	if c.ReadOnly {
+		transport = newReadOnlyTransport(transport)
+	}
+
+	return &http.Client{
+		Transport: transport,
+	}
+}
+
+// clientOptions returns the options used to create an API service, overriding
+// the service's base path if a custom endpoint is configured.
+func (c *apiClient) clientOptions(client *http.Client, customEndpoint string) []option.ClientOption {
+	opts := []option.ClientOption{option.WithHTTPClient(client)}
+
+	if customEndpoint != "" {
+		log.Printf("[INFO] Using custom endpoint %q", customEndpoint)
+
+		// the generated clients resolve request paths relative to the base path
+		if !strings.HasSuffix(customEndpoint, "/") {
+			customEndpoint += "/"
+		}
+		opts = append(opts, option.WithEndpoint(customEndpoint))
+	}
+
+	return opts
 }
 
 func (c *apiClient) NewChromePolicyService() (*chromepolicy.Service, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
+	c.servicesMutex.Lock()
+	defer c.servicesMutex.Unlock()
+
+	if c.chromePolicyService != nil {
+		return c.chromePolicyService, diags
+	}
+
 	log.Printf("[INFO] Instantiating Google Admin Chrome Policy service")
 
-	chromePolicyService, err := chromepolicy.NewService(context.Background(), option.WithHTTPClient(c.client))
+	chromePolicyService, err := chromepolicy.NewService(context.Background(), c.clientOptions(c.apiHTTPClient(chromePolicyApi), c.ChromePolicyCustomEndpoint)...)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -85,15 +317,24 @@ func (c *apiClient) NewChromePolicyService() (*chromepolicy.Service, diag.Diagno
 		return nil, diags
 	}
 
+	c.chromePolicyService = chromePolicyService
+
 	return chromePolicyService, diags
 }
 
 func (c *apiClient) NewDirectoryService() (*directory.Service, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
+	c.servicesMutex.Lock()
+	defer c.servicesMutex.Unlock()
+
+	if c.directoryService != nil {
+		return c.directoryService, diags
+	}
+
 	log.Printf("[INFO] Instantiating Google Admin Directory service")
 
-	directoryService, err := directory.NewService(context.Background(), option.WithHTTPClient(c.client))
+	directoryService, err := directory.NewService(context.Background(), c.clientOptions(c.apiHTTPClient(directoryApi), c.DirectoryCustomEndpoint)...)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -107,29 +348,52 @@ func (c *apiClient) NewDirectoryService() (*directory.Service, diag.Diagnostics)
 		return nil, diags
 	}
 
+	c.directoryService = directoryService
+
 	return directoryService, diags
 }
+
 func (c *apiClient) NewGmailService(ctx context.Context, userId string) (*gmail.Service, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
+	// Each service impersonates a single user, reusing it also reuses its token
+	c.servicesMutex.Lock()
+	gmailService, ok := c.gmailServices[userId]
+	c.servicesMutex.Unlock()
+	if ok {
+		return gmailService, diags
+	}
+
 	log.Printf("[INFO] Instantiating Google Admin Gmail service")
 
 	// the send-as-alias resource requires the oauth token impersonate the user
 	// the alias is being created for.
 	log.Printf("[INFO] Creating Google Admin Gmail client that impersonates %q", userId)
 	newClient := &apiClient{
-		Credentials:           c.Credentials,
-		ClientScopes:          c.ClientScopes,
-		Customer:              c.Customer,
-		UserAgent:             c.UserAgent,
-		ImpersonatedUserEmail: userId,
+		Credentials:               c.Credentials,
+		ClientScopes:              c.ClientScopes,
+		Customer:                  c.Customer,
+		UserAgent:                 c.UserAgent,
+		ImpersonateServiceAccount: c.ImpersonateServiceAccount,
+		ImpersonatedUserEmail:     userId,
+		ReadOnly:                  c.ReadOnly,
+		RetryPolicy:               c.RetryPolicy,
+		GmailCustomEndpoint:       c.GmailCustomEndpoint,
+
+		// share the rate limiters so the configured rates apply across all users
+		rateLimiters: c.rateLimiters,
+		auditLogger:  c.auditLogger,
+		baseClient:   c.baseClient,
 	}
+
+	// The credentials are loaded without holding the lock, as that may send
+	// requests, which would block every other service lookup in the meantime.
 	diags = newClient.loadAndValidate(ctx)
 	if diags.HasError() {
 		return nil, diags
 	}
 
-	gmailService, err := gmail.NewService(ctx, option.WithHTTPClient(newClient.client))
+	gmailService, err := gmail.NewService(ctx, c.clientOptions(newClient.apiHTTPClient(gmailApi), c.GmailCustomEndpoint)...)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -143,15 +407,36 @@ func (c *apiClient) NewGmailService(ctx context.Context, userId string) (*gmail.
 		return nil, diags
 	}
 
+	c.servicesMutex.Lock()
+	defer c.servicesMutex.Unlock()
+
+	// the same user's service may have been created concurrently, only one of
+	// them is kept so its token is reused
+	if existing, ok := c.gmailServices[userId]; ok {
+		return existing, diags
+	}
+
+	if c.gmailServices == nil {
+		c.gmailServices = map[string]*gmail.Service{}
+	}
+	c.gmailServices[userId] = gmailService
+
 	return gmailService, diags
 }
 
 func (c *apiClient) NewGroupsSettingsService() (*groupssettings.Service, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
+	c.servicesMutex.Lock()
+	defer c.servicesMutex.Unlock()
+
+	if c.groupsSettingsService != nil {
+		return c.groupsSettingsService, diags
+	}
+
 	log.Printf("[INFO] Instantiating Google Admin Groups Settings service")
 
-	groupsSettingsService, err := groupssettings.NewService(context.Background(), option.WithHTTPClient(c.client))
+	groupsSettingsService, err := groupssettings.NewService(context.Background(), c.clientOptions(c.apiHTTPClient(groupsSettingsApi), c.GroupsSettingsCustomEndpoint)...)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
@@ -165,5 +450,7 @@ func (c *apiClient) NewGroupsSettingsService() (*groupssettings.Service, diag.Di
 		return nil, diags
 	}
 
+	c.groupsSettingsService = groupsSettingsService
+
 	return groupsSettingsService, diags
 }
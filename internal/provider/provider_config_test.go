@@ -4,8 +4,16 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
 	"testing"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/gmail/v1"
 )
 
 func TestConfigLoadAndValidate_credsInvalidJSON(t *testing.T) {
@@ -114,3 +122,228 @@ func TestConfigOauthScopes_custom(t *testing.T) {
 		t.Fatalf("expected scope to be %q, got %q", "https://www.googleapis.com/auth/admin/directory", config.ClientScopes[0])
 	}
 }
+
+func TestConfigLoadAndValidate_applicationDefaultCredentials(t *testing.T) {
+	oldEnv := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	defer os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", oldEnv)
+	os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", testFakeCredentialsPath)
+
+	config := &apiClient{
+		ImpersonatedUserEmail: "my-fake-email@example.com",
+	}
+
+	diags := config.loadAndValidate(context.Background())
+	err := checkDiags(diags)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if config.client == nil {
+		t.Fatalf("expected client to be set from application default credentials")
+	}
+}
+
+func TestConfigLoadAndValidate_externalAccount(t *testing.T) {
+	config := &apiClient{
+		Credentials: testFakeExternalAccountPath,
+	}
+
+	diags := config.loadAndValidate(context.Background())
+	err := checkDiags(diags)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if config.client == nil {
+		t.Fatalf("expected client to be set from external account credentials")
+	}
+}
+
+// The token is exchanged on the first request, after the context the provider
+// is configured with is canceled.
+func TestConfigLoadAndValidate_externalAccountCanceledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token": "sts-token", "issued_token_type": "urn:ietf:params:oauth:token-type:access_token", "token_type": "Bearer", "expires_in": 3600}`))
+		case "/check":
+			if got := r.Header.Get("Authorization"); got != "Bearer sts-token" {
+				t.Errorf("expected the exchanged token, got %q", got)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	subjectTokenPath := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(subjectTokenPath, []byte("subject-token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &apiClient{
+		Credentials: fmt.Sprintf(`{
+  "type": "external_account",
+  "audience": "//iam.googleapis.com/projects/123456789/locations/global/workloadIdentityPools/foo/providers/bar",
+  "subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+  "token_url": "%s/token",
+  "credential_source": {
+    "file": "%s"
+  }
+}`, server.URL, subjectTokenPath),
+	}
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), oauth2.HTTPClient, server.Client()))
+	diags := config.loadAndValidate(ctx)
+	cancel()
+	if err := checkDiags(diags); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	resp, err := config.client.Get(server.URL + "/check")
+	if err != nil {
+		t.Fatalf("error sending request after the context was canceled: %s", err)
+	}
+	resp.Body.Close()
+}
+
+func TestConfigLoadAndValidate_externalAccountImpersonation(t *testing.T) {
+	config := &apiClient{
+		Credentials:           testFakeExternalAccountPath,
+		ImpersonatedUserEmail: "my-fake-email@example.com",
+	}
+
+	diags := config.loadAndValidate(context.Background())
+	if !diags.HasError() {
+		t.Fatalf("expected error, but got nil")
+	}
+}
+
+func TestConfigCustomEndpoints(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := &apiClient{
+		client:                       server.Client(),
+		Customer:                     "my_customer",
+		DirectoryCustomEndpoint:      server.URL + "/directory",
+		GroupsSettingsCustomEndpoint: server.URL + "/groups/v1/groups/",
+	}
+
+	directoryService, diags := config.NewDirectoryService()
+	if diags.HasError() {
+		t.Fatalf(diags[0].Summary)
+	}
+
+	if _, err := directoryService.Customers.Get(config.Customer).Do(); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	groupsSettingsService, diags := config.NewGroupsSettingsService()
+	if diags.HasError() {
+		t.Fatalf(diags[0].Summary)
+	}
+
+	if _, err := groupsSettingsService.Groups.Get("my-group@example.com").Do(); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	expected := []string{
+		"/directory/admin/directory/v1/customers/my_customer",
+		"/groups/v1/groups/my-group@example.com",
+	}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("expected requests to %v, got %v", expected, paths)
+	}
+}
+
+func TestConfigServices_reused(t *testing.T) {
+	config := &apiClient{
+		Credentials: testFakeCredentialsPath,
+	}
+
+	diags := config.loadAndValidate(context.Background())
+	err := checkDiags(diags)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	directoryService, diags := config.NewDirectoryService()
+	if diags.HasError() {
+		t.Fatalf(diags[0].Summary)
+	}
+
+	sameDirectoryService, diags := config.NewDirectoryService()
+	if diags.HasError() {
+		t.Fatalf(diags[0].Summary)
+	}
+
+	if directoryService != sameDirectoryService {
+		t.Fatalf("expected the directory service to be reused")
+	}
+
+	gmailService, diags := config.NewGmailService(context.Background(), "user1@example.com")
+	if diags.HasError() {
+		t.Fatalf(diags[0].Summary)
+	}
+
+	sameGmailService, diags := config.NewGmailService(context.Background(), "user1@example.com")
+	if diags.HasError() {
+		t.Fatalf(diags[0].Summary)
+	}
+
+	if gmailService != sameGmailService {
+		t.Fatalf("expected the gmail service to be reused for the same user")
+	}
+
+	otherGmailService, diags := config.NewGmailService(context.Background(), "user2@example.com")
+	if diags.HasError() {
+		t.Fatalf(diags[0].Summary)
+	}
+
+	if gmailService == otherGmailService {
+		t.Fatalf("expected a different gmail service for a different user")
+	}
+}
+
+func TestConfigServices_gmailConcurrent(t *testing.T) {
+	config := &apiClient{
+		Credentials: testFakeCredentialsPath,
+	}
+
+	diags := config.loadAndValidate(context.Background())
+	err := checkDiags(diags)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	services := make([]*gmail.Service, 10)
+
+	var wg sync.WaitGroup
+	for i := range services {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			gmailService, diags := config.NewGmailService(context.Background(), "user1@example.com")
+			if diags.HasError() {
+				t.Errorf(diags[0].Summary)
+			}
+			services[i] = gmailService
+		}(i)
+	}
+	wg.Wait()
+
+	for _, gmailService := range services {
+		if gmailService != services[0] {
+			t.Fatalf("expected the gmail service to be reused for the same user")
+		}
+	}
+}
@@ -12,7 +12,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-const testFakeCredentialsPath = "./test-data/fake-creds.json"
+const (
+	testFakeCredentialsPath     = "./test-data/fake-creds.json"
+	testFakeExternalAccountPath = "./test-data/fake-external-account.json"
+)
 
 // providerFactories are used to instantiate a provider during acceptance testing.
 // The factory function will be invoked for every Terraform CLI command executed
@@ -48,8 +51,14 @@ func getTestImpersonatedUserFromEnv() string {
 
 // googleworkspaceTestClient returns a common client
 func googleworkspaceTestClient() (*apiClient, error) {
+	return googleworkspaceTestClientWithContext(context.Background())
+}
+
+// googleworkspaceTestClientWithContext returns a client that sends requests
+// with the HTTP client in the context, if any.
+func googleworkspaceTestClientWithContext(ctx context.Context) (*apiClient, error) {
 	creds := getTestCredsFromEnv()
-	if creds == "" {
+	if creds == "" && os.Getenv("GOOGLEWORKSPACE_USE_DEFAULT_CREDENTIALS") != "true" {
 		return nil, fmt.Errorf("set credentials using any of these env variables %v", credsEnvVars)
 	}
 
@@ -67,9 +76,14 @@ func googleworkspaceTestClient() (*apiClient, error) {
 		Credentials:           creds,
 		Customer:              customerId,
 		ImpersonatedUserEmail: impersonatedUser,
+
+		ChromePolicyCustomEndpoint:   os.Getenv("GOOGLEWORKSPACE_CHROME_POLICY_CUSTOM_ENDPOINT"),
+		DirectoryCustomEndpoint:      os.Getenv("GOOGLEWORKSPACE_DIRECTORY_CUSTOM_ENDPOINT"),
+		GmailCustomEndpoint:          os.Getenv("GOOGLEWORKSPACE_GMAIL_CUSTOM_ENDPOINT"),
+		GroupsSettingsCustomEndpoint: os.Getenv("GOOGLEWORKSPACE_GROUPS_SETTINGS_CUSTOM_ENDPOINT"),
 	}
 
-	diags := client.loadAndValidate(context.Background())
+	diags := client.loadAndValidate(ctx)
 	if diags.HasError() {
 		log.Printf("[INFO][SWEEPER_LOG] error loading: %s", diags[0].Summary)
 		return nil, fmt.Errorf(diags[0].Summary)
@@ -0,0 +1,57 @@
+package googleworkspace
+
+import (
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// The APIs that requests are rate limited for, each has its own quota.
+const (
+	chromePolicyApi   = "chromepolicy"
+	directoryApi      = "directory"
+	gmailApi          = "gmail"
+	groupsSettingsApi = "groupssettings"
+)
+
+var rateLimitedApis = []string{chromePolicyApi, directoryApi, gmailApi, groupsSettingsApi}
+
+// rateLimit configures the token bucket used to limit requests to an API.
+type rateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// newRateLimiters returns a limiter for each API with a configured rate limit.
+// The limiters are shared by every client created from the provider, so the
+// parallelism of Terraform can't exceed the configured rates.
+func newRateLimiters(rateLimits map[string]rateLimit) map[string]*rate.Limiter {
+	limiters := map[string]*rate.Limiter{}
+
+	for api, limit := range rateLimits {
+		limiters[api] = rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), limit.Burst)
+	}
+
+	return limiters
+}
+
+// rateLimitTransport waits for the limiter before sending each request.
+type rateLimitTransport struct {
+	base    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+func newRateLimitTransport(base http.RoundTripper, limiter *rate.Limiter) *rateLimitTransport {
+	return &rateLimitTransport{
+		base:    base,
+		limiter: limiter,
+	}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,61 @@
+package googleworkspace
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := &apiClient{
+		client: server.Client(),
+		rateLimiters: newRateLimiters(map[string]rateLimit{
+			directoryApi: {RequestsPerSecond: 20, Burst: 1},
+		}),
+	}
+
+	limited := config.apiHTTPClient(directoryApi)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		resp, err := limited.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		resp.Body.Close()
+	}
+
+	// the first request uses the burst, the next 4 wait 50ms each
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("expected requests to be rate limited, 5 requests took %s", elapsed)
+	}
+
+	unlimited := config.apiHTTPClient(groupsSettingsApi)
+	start = time.Now()
+	for i := 0; i < 5; i++ {
+		resp, err := unlimited.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		resp.Body.Close()
+	}
+
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Fatalf("expected requests to other apis not to be rate limited, 5 requests took %s", elapsed)
+	}
+}
+
+func TestExpandRateLimits_duplicate(t *testing.T) {
+	_, diags := expandRateLimits([]interface{}{
+		map[string]interface{}{"api": directoryApi, "requests_per_second": 10.0, "burst": 1},
+		map[string]interface{}{"api": directoryApi, "requests_per_second": 5.0, "burst": 1},
+	})
+	if !diags.HasError() {
+		t.Fatalf("expected error, but got nil")
+	}
+}
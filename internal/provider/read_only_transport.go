@@ -0,0 +1,58 @@
+package googleworkspace
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Requests that use POST but don't change the tenant, and are allowed in read only mode.
+var readOnlyPostSuffixes = []string{
+	// resolves the policies applied to an org unit or group
+	"/policies:resolve",
+	// lists the priority ordering of the groups an app's policies apply to
+	"/policies/groups:listGroupPriorityOrdering",
+}
+
+// readOnlyTransport fails every request that could change the tenant, so the
+// provider can be trusted with credentials that are able to.
+type readOnlyTransport struct {
+	base http.RoundTripper
+}
+
+func newReadOnlyTransport(base http.RoundTripper) *readOnlyTransport {
+	return &readOnlyTransport{
+		base: base,
+	}
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isReadOnlyRequest(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	if resource, ok := resourceInfoFromContext(req.Context()); ok {
+		return nil, fmt.Errorf("%s would change the tenant with %s %s, but the provider is configured with read_only", resource, req.Method, apiResourcePath(req.URL))
+	}
+
+	return nil, fmt.Errorf("%s %s would change the tenant, but the provider is configured with read_only", req.Method, apiResourcePath(req.URL))
+}
+
+func isReadOnlyRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		for _, suffix := range readOnlyPostSuffixes {
+			if strings.HasSuffix(req.URL.Path, suffix) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,97 @@
+package googleworkspace
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	directory "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/chromepolicy/v1"
+)
+
+func TestReadOnlyTransport(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	config := &apiClient{
+		client:                     server.Client(),
+		ReadOnly:                   true,
+		ChromePolicyCustomEndpoint: server.URL,
+		DirectoryCustomEndpoint:    server.URL,
+	}
+
+	directoryService, diags := config.NewDirectoryService()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	chromePolicyService, diags := config.NewChromePolicyService()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	r := withResourceInfo("googleworkspace_user", &schema.Resource{
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			if _, err := directoryService.Users.Get(d.Id()).Context(ctx).Do(); err != nil {
+				return diag.FromErr(err)
+			}
+
+			if _, err := directoryService.Users.Update(d.Id(), &directory.User{}).Context(ctx).Do(); err != nil {
+				return diag.FromErr(err)
+			}
+
+			return nil
+		},
+	})
+
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+	d.SetId("123")
+
+	diags = r.UpdateContext(context.Background(), d, config)
+	if !diags.HasError() {
+		t.Fatalf("expected error, but got nil")
+	}
+	if !strings.Contains(diags[0].Summary, `googleworkspace_user "123"`) || !strings.Contains(diags[0].Summary, "read_only") {
+		t.Fatalf("expected error to name the resource and read_only, got %q", diags[0].Summary)
+	}
+
+	// resolving policies is a POST, but doesn't change the tenant
+	if _, err := chromePolicyService.Customers.Policies.Resolve("customers/my_customer", &chromepolicy.GoogleChromePolicyV1ResolveRequest{}).Do(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	chromePolicyGroupsService, diags := config.NewChromePolicyGroupsService()
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	// so is listing the priority ordering of groups, but updating it isn't
+	if _, err := chromePolicyGroupsService.ListGroupPriorityOrdering(context.Background(), "customers/my_customer", &chromePolicyGroupPriorityOrdering{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := chromePolicyGroupsService.UpdateGroupPriorityOrdering(context.Background(), "customers/my_customer", &chromePolicyGroupPriorityOrdering{}); err == nil {
+		t.Fatalf("expected error, but got nil")
+	}
+
+	if err := directoryService.Users.Delete("123").Do(); err == nil {
+		t.Fatalf("expected error, but got nil")
+	}
+
+	expected := []string{
+		"GET /admin/directory/v1/users/123",
+		"POST /v1/customers/my_customer/policies:resolve",
+		"POST /v1/customers/my_customer/policies/groups:listGroupPriorityOrdering",
+	}
+	if strings.Join(requests, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected requests %v, got %v", expected, requests)
+	}
+}
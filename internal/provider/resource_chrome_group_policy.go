@@ -0,0 +1,234 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/api/chromepolicy/v1"
+)
+
+func resourceChromeGroupPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Chrome Group Policy resource in the Terraform Googleworkspace provider. Chrome Group Policy " +
+			"applies policies to the members of a group, whichever org unit they're in. Only some policies, such as " +
+			"those of apps, can be applied to groups. When the policies of more than one group apply to an app, the " +
+			"order in which they apply is managed by `googleworkspace_chrome_group_priority_ordering`.",
+
+		CreateContext: resourceChromeGroupPolicyCreate,
+		UpdateContext: resourceChromeGroupPolicyUpdate,
+		ReadContext:   resourceChromeGroupPolicyRead,
+		DeleteContext: resourceChromeGroupPolicyDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceChromeGroupPolicyImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Description: "The ID of the group on which this policy is applied.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"additional_target_keys": {
+				Description: "Additional keys that identify the target of the policies, such as `app_id` for the " +
+					"policies of an app. Every policy must have the same `additional_target_key_names` in its schema, " +
+					"which are listed by the `googleworkspace_chrome_policy_schema` data source.",
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"policies": {
+				Description: "Policies to set for the group",
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"schema_name": {
+							Description: "The full qualified name of the policy schema.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"schema_values": {
+							Description: "JSON encoded map that represents key/value pairs that " +
+								"correspond to the given schema. ",
+							Type:     schema.TypeMap,
+							Required: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								ValidateDiagFunc: validation.ToDiagFunc(
+									validation.StringIsJSON,
+								),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceChromeGroupPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	groupId := d.Get("group_id").(string)
+
+	log.Printf("[DEBUG] Creating Chrome Group Policy for group:%s", groupId)
+
+	policyTargetKey := chromeGroupPolicyTargetKey(d)
+
+	diags := createChromePolicies(ctx, d, client, policyTargetKey, modifyChromeGroupPolicies)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished creating Chrome Group Policy for group:%s", groupId)
+	d.SetId(chromePolicyId(groupId, policyTargetKey.AdditionalTargetKeys))
+
+	return resourceChromeGroupPolicyRead(ctx, d, meta)
+}
+
+func resourceChromeGroupPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	log.Printf("[DEBUG] Updating Chrome Group Policy for group:%s", d.Id())
+
+	// Update is achieved by deleting the previous policySchemas, and then applying the new set
+	old, _ := d.GetChange("policies")
+
+	diags := removeChromePolicies(ctx, client, chromeGroupPolicyTargetKey(d), old.([]interface{}), deleteChromeGroupPolicies)
+	if diags.HasError() {
+		return diags
+	}
+
+	// run create
+	diags = resourceChromeGroupPolicyCreate(ctx, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished Updating Chrome Group Policy for group:%s", d.Id())
+
+	return diags
+}
+
+func resourceChromeGroupPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	log.Printf("[DEBUG] Getting Chrome Group Policy for group:%s", d.Id())
+
+	policies, diags := readChromePolicies(ctx, d, client, chromeGroupPolicyTargetKey(d), false)
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("policies", policies); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Finished getting Chrome Group Policy for group:%s", d.Id())
+	return nil
+}
+
+func resourceChromeGroupPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	log.Printf("[DEBUG] Deleting Chrome Group Policy for group:%s", d.Id())
+
+	diags := removeChromePolicies(ctx, client, chromeGroupPolicyTargetKey(d), d.Get("policies").([]interface{}), deleteChromeGroupPolicies)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished deleting Chrome Group Policy for group:%s", d.Id())
+	return nil
+}
+
+// id is of format "<group_id>/<policy_schema_filters>", optionally followed
+// by the additional target keys as an encoded query string.
+func resourceChromeGroupPolicyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*apiClient)
+
+	groupId, filters, additionalTargetKeys, err := parseChromePolicyImportId(d.Id(), "<group_id>/<policy_schema_filters>[?<additional_target_keys>]")
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("group_id", groupId)
+	d.Set("additional_target_keys", additionalTargetKeys)
+
+	log.Printf("[DEBUG] Importing Chrome Group Policy for group:%s", groupId)
+
+	policies, err := importChromePolicies(ctx, client, chromeGroupPolicyTargetKey(d), filters)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(policies) == 0 {
+		return nil, fmt.Errorf("no policies matching %s are set on group %s", filters, groupId)
+	}
+
+	if err := d.Set("policies", policies); err != nil {
+		return nil, err
+	}
+
+	d.SetId(chromePolicyId(groupId, additionalTargetKeys))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// modifyChromeGroupPolicies sets the fields of the policies of a group that
+// are named by their update masks.
+func modifyChromeGroupPolicies(ctx context.Context, client *apiClient, policyTargetKey *chromepolicy.GoogleChromePolicyV1PolicyTargetKey, policies []*chromepolicy.GoogleChromePolicyV1PolicyValue, updateMasks []string) error {
+	chromePolicyGroupsService, diags := client.NewChromePolicyGroupsService()
+	if diags.HasError() {
+		return fmt.Errorf("%s", diags[0].Summary)
+	}
+
+	var requests []*chromePolicyModifyGroupPolicyRequest
+	for i, p := range policies {
+		requests = append(requests, &chromePolicyModifyGroupPolicyRequest{
+			PolicyTargetKey: policyTargetKey,
+			PolicyValue:     p,
+			UpdateMask:      updateMasks[i],
+		})
+	}
+
+	return chromePolicyGroupsService.BatchModify(ctx, fmt.Sprintf("customers/%s", client.Customer), requests)
+}
+
+// deleteChromeGroupPolicies removes the policies from a group, as a group
+// doesn't inherit them.
+func deleteChromeGroupPolicies(ctx context.Context, client *apiClient, policyTargetKey *chromepolicy.GoogleChromePolicyV1PolicyTargetKey, schemaNames []string) error {
+	chromePolicyGroupsService, diags := client.NewChromePolicyGroupsService()
+	if diags.HasError() {
+		return fmt.Errorf("%s", diags[0].Summary)
+	}
+
+	var requests []*chromePolicyDeleteGroupPolicyRequest
+	for _, schemaName := range schemaNames {
+		requests = append(requests, &chromePolicyDeleteGroupPolicyRequest{
+			PolicyTargetKey: policyTargetKey,
+			PolicySchema:    schemaName,
+		})
+	}
+
+	return chromePolicyGroupsService.BatchDelete(ctx, fmt.Sprintf("customers/%s", client.Customer), requests)
+}
+
+// chromeGroupPolicyTargetKey is the target of the policies, which is the
+// group, and the app within it that's identified by the additional target
+// keys.
+func chromeGroupPolicyTargetKey(d *schema.ResourceData) *chromepolicy.GoogleChromePolicyV1PolicyTargetKey {
+	return &chromepolicy.GoogleChromePolicyV1PolicyTargetKey{
+		TargetResource:       "groups/" + d.Get("group_id").(string),
+		AdditionalTargetKeys: expandChromePolicyAdditionalTargetKeys(d),
+	}
+}
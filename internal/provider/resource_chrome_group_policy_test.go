@@ -0,0 +1,88 @@
+package googleworkspace
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceChromeGroupPolicy_basic(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	email := fmt.Sprintf("tf-test-%s@%s", randString(t, 10), domainName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceChromeGroupPolicy_basic(email, "APP_INSTALL_TYPE_ENUM_FORCED"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_chrome_group_policy.test", "additional_target_keys.app_id", "chrome:aapbdbdomjkkjkaonfhkkikfgjllcleb"),
+					resource.TestCheckResourceAttr("googleworkspace_chrome_group_policy.test", "policies.#", "1"),
+					resource.TestCheckResourceAttr("googleworkspace_chrome_group_policy.test", "policies.0.schema_name", "chrome.users.apps.InstallType"),
+					resource.TestCheckResourceAttr("googleworkspace_chrome_group_policy.test", "policies.0.schema_values.appInstallType", encode("APP_INSTALL_TYPE_ENUM_FORCED")),
+				),
+			},
+			{
+				ResourceName:      "googleworkspace_chrome_group_policy.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccResourceChromeGroupPolicyImportStateIdFunc("googleworkspace_chrome_group_policy.test", "chrome.users.apps.*"),
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccResourceChromeGroupPolicy_basic(email, "APP_INSTALL_TYPE_ENUM_BLOCKED"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_chrome_group_policy.test", "policies.0.schema_values.appInstallType", encode("APP_INSTALL_TYPE_ENUM_BLOCKED")),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceChromeGroupPolicyImportStateIdFunc(resourceName, filters string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		values := url.Values{}
+		values.Set("app_id", rs.Primary.Attributes["additional_target_keys.app_id"])
+
+		return fmt.Sprintf("%s/%s?%s", rs.Primary.Attributes["group_id"], filters, values.Encode()), nil
+	}
+}
+
+func testAccResourceChromeGroupPolicy_basic(email, installType string) string {
+	return fmt.Sprintf(`
+resource "googleworkspace_group" "test" {
+  email = "%s"
+}
+
+resource "googleworkspace_chrome_group_policy" "test" {
+  group_id = googleworkspace_group.test.id
+
+  additional_target_keys = {
+    "app_id" = "chrome:aapbdbdomjkkjkaonfhkkikfgjllcleb"
+  }
+
+  policies {
+    schema_name = "chrome.users.apps.InstallType"
+    schema_values = {
+      appInstallType = jsonencode("%s")
+    }
+  }
+}
+`, email, installType)
+}
@@ -0,0 +1,187 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/api/chromepolicy/v1"
+)
+
+func resourceChromeGroupPriorityOrdering() *schema.Resource {
+	return &schema.Resource{
+		Description: "Chrome Group Priority Ordering resource in the Terraform Googleworkspace provider. Chrome Group " +
+			"Priority Ordering manages the order in which the policies of groups apply to an app, for users that are " +
+			"members of more than one of the groups. The ordering has every group that has policies for the app, so " +
+			"those policies have to be set first. Destroying the resource leaves the ordering as it is, as the groups " +
+			"always have one while they have policies for the app.",
+
+		CreateContext: resourceChromeGroupPriorityOrderingCreate,
+		UpdateContext: resourceChromeGroupPriorityOrderingUpdate,
+		ReadContext:   resourceChromeGroupPriorityOrderingRead,
+		DeleteContext: resourceChromeGroupPriorityOrderingDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceChromeGroupPriorityOrderingImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"policy_namespace": {
+				Description: "The namespace of the policies, such as `chrome.users.apps`.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"additional_target_keys": {
+				Description: "Additional keys that identify the app the policies apply to, such as `app_id`.",
+				Type:        schema.TypeMap,
+				Required:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"group_ids": {
+				Description: "The IDs of the groups that have policies for the app, from the highest priority to the lowest.",
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceChromeGroupPriorityOrderingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	policyNamespace := d.Get("policy_namespace").(string)
+
+	log.Printf("[DEBUG] Creating Chrome Group Priority Ordering for %s", policyNamespace)
+
+	diags := updateChromeGroupPriorityOrdering(ctx, d, meta.(*apiClient))
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished creating Chrome Group Priority Ordering for %s", policyNamespace)
+	d.SetId(chromePolicyId(policyNamespace, expandChromePolicyAdditionalTargetKeys(d)))
+
+	return resourceChromeGroupPriorityOrderingRead(ctx, d, meta)
+}
+
+func resourceChromeGroupPriorityOrderingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Updating Chrome Group Priority Ordering %s", d.Id())
+
+	diags := updateChromeGroupPriorityOrdering(ctx, d, meta.(*apiClient))
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished Updating Chrome Group Priority Ordering %s", d.Id())
+
+	return resourceChromeGroupPriorityOrderingRead(ctx, d, meta)
+}
+
+func resourceChromeGroupPriorityOrderingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	chromePolicyGroupsService, diags := client.NewChromePolicyGroupsService()
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Getting Chrome Group Priority Ordering %s", d.Id())
+
+	var resp *chromePolicyGroupPriorityOrdering
+	err := retryTimeDuration(ctx, time.Minute, func() error {
+		var retryErr error
+
+		resp, retryErr = chromePolicyGroupsService.ListGroupPriorityOrdering(ctx, fmt.Sprintf("customers/%s", client.Customer), &chromePolicyGroupPriorityOrdering{
+			PolicyTargetKey: chromeGroupPriorityOrderingTargetKey(d),
+			PolicyNamespace: d.Get("policy_namespace").(string),
+		})
+		return retryErr
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("group_ids", resp.GroupIds); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Finished getting Chrome Group Priority Ordering %s", d.Id())
+	return nil
+}
+
+// The ordering can't be removed while the groups have policies for the app,
+// so it's only removed from the state.
+func resourceChromeGroupPriorityOrderingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Removing Chrome Group Priority Ordering %s from state, the ordering is left as it is", d.Id())
+
+	d.SetId("")
+	return nil
+}
+
+// id is of format "<policy_namespace>?<additional_target_keys>", with the
+// additional target keys as an encoded query string.
+func resourceChromeGroupPriorityOrderingImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "?", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Chrome Group Priority Ordering Id (%s) is not of the correct format (<policy_namespace>?<additional_target_keys>)", d.Id())
+	}
+
+	values, err := url.ParseQuery(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("Chrome Group Priority Ordering Id (%s) has invalid additional target keys: %s", d.Id(), err)
+	}
+
+	additionalTargetKeys := map[string]string{}
+	for k := range values {
+		additionalTargetKeys[k] = values.Get(k)
+	}
+
+	d.Set("policy_namespace", parts[0])
+	d.Set("additional_target_keys", additionalTargetKeys)
+	d.SetId(chromePolicyId(parts[0], additionalTargetKeys))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func updateChromeGroupPriorityOrdering(ctx context.Context, d *schema.ResourceData, client *apiClient) diag.Diagnostics {
+	chromePolicyGroupsService, diags := client.NewChromePolicyGroupsService()
+	if diags.HasError() {
+		return diags
+	}
+
+	var groupIds []string
+	for _, id := range d.Get("group_ids").([]interface{}) {
+		groupIds = append(groupIds, id.(string))
+	}
+
+	err := retryTimeDuration(ctx, time.Minute, func() error {
+		return chromePolicyGroupsService.UpdateGroupPriorityOrdering(ctx, fmt.Sprintf("customers/%s", client.Customer), &chromePolicyGroupPriorityOrdering{
+			PolicyTargetKey: chromeGroupPriorityOrderingTargetKey(d),
+			PolicyNamespace: d.Get("policy_namespace").(string),
+			GroupIds:        groupIds,
+		})
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// chromeGroupPriorityOrderingTargetKey is the app the ordering is of, which is
+// only identified by the additional target keys.
+func chromeGroupPriorityOrderingTargetKey(d *schema.ResourceData) *chromepolicy.GoogleChromePolicyV1PolicyTargetKey {
+	return &chromepolicy.GoogleChromePolicyV1PolicyTargetKey{
+		AdditionalTargetKeys: expandChromePolicyAdditionalTargetKeys(d),
+	}
+}
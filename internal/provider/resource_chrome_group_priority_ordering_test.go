@@ -0,0 +1,108 @@
+package googleworkspace
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceChromeGroupPriorityOrdering_basic(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	prefix := fmt.Sprintf("tf-test-%s", randString(t, 10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceChromeGroupPriorityOrdering(prefix, domainName, "pilot", "blocked"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_chrome_group_priority_ordering.test", "group_ids.#", "2"),
+					resource.TestCheckResourceAttrPair("googleworkspace_chrome_group_priority_ordering.test", "group_ids.0", "googleworkspace_group.pilot", "id"),
+					resource.TestCheckResourceAttrPair("googleworkspace_chrome_group_priority_ordering.test", "group_ids.1", "googleworkspace_group.blocked", "id"),
+				),
+			},
+			{
+				ResourceName:      "googleworkspace_chrome_group_priority_ordering.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccResourceChromeGroupPriorityOrdering(prefix, domainName, "blocked", "pilot"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("googleworkspace_chrome_group_priority_ordering.test", "group_ids.0", "googleworkspace_group.blocked", "id"),
+					resource.TestCheckResourceAttrPair("googleworkspace_chrome_group_priority_ordering.test", "group_ids.1", "googleworkspace_group.pilot", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceChromeGroupPriorityOrdering(prefix, domainName, first, second string) string {
+	return fmt.Sprintf(`
+resource "googleworkspace_group" "pilot" {
+  email = "%[1]s-pilot@%[2]s"
+}
+
+resource "googleworkspace_group" "blocked" {
+  email = "%[1]s-blocked@%[2]s"
+}
+
+resource "googleworkspace_chrome_group_policy" "pilot" {
+  group_id = googleworkspace_group.pilot.id
+
+  additional_target_keys = {
+    "app_id" = "chrome:aapbdbdomjkkjkaonfhkkikfgjllcleb"
+  }
+
+  policies {
+    schema_name = "chrome.users.apps.InstallType"
+    schema_values = {
+      appInstallType = jsonencode("APP_INSTALL_TYPE_ENUM_FORCED")
+    }
+  }
+}
+
+resource "googleworkspace_chrome_group_policy" "blocked" {
+  group_id = googleworkspace_group.blocked.id
+
+  additional_target_keys = {
+    "app_id" = "chrome:aapbdbdomjkkjkaonfhkkikfgjllcleb"
+  }
+
+  policies {
+    schema_name = "chrome.users.apps.InstallType"
+    schema_values = {
+      appInstallType = jsonencode("APP_INSTALL_TYPE_ENUM_BLOCKED")
+    }
+  }
+}
+
+resource "googleworkspace_chrome_group_priority_ordering" "test" {
+  policy_namespace = "chrome.users.apps"
+
+  additional_target_keys = {
+    "app_id" = "chrome:aapbdbdomjkkjkaonfhkkikfgjllcleb"
+  }
+
+  group_ids = [
+    googleworkspace_group.%[3]s.id,
+    googleworkspace_group.%[4]s.id,
+  ]
+
+  depends_on = [
+    googleworkspace_chrome_group_policy.pilot,
+    googleworkspace_chrome_group_policy.blocked,
+  ]
+}
+`, prefix, domainName, first, second)
+}
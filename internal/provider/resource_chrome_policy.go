@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -18,13 +20,19 @@ import (
 
 func resourceChromePolicy() *schema.Resource {
 	return &schema.Resource{
-		Description: "Chrome Policy resource in the Terraform Googleworkspace provider. Currently only supports policies not requiring additionalTargetKeys.",
+		Description: "Chrome Policy resource in the Terraform Googleworkspace provider. Policies that require " +
+			"additionalTargetKeys, such as the policies of apps and printers, are applied to the target identified " +
+			"by `additional_target_keys`.",
 
 		CreateContext: resourceChromePolicyCreate,
 		UpdateContext: resourceChromePolicyUpdate,
 		ReadContext:   resourceChromePolicyRead,
 		DeleteContext: resourceChromePolicyDelete,
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceChromePolicyImport,
+		},
+
 		Schema: map[string]*schema.Schema{
 			"org_unit_id": {
 				Description:      "The target org unit on which this policy is applied.",
@@ -33,6 +41,18 @@ func resourceChromePolicy() *schema.Resource {
 				ForceNew:         true,
 				DiffSuppressFunc: diffSuppressOrgUnitId,
 			},
+			"additional_target_keys": {
+				Description: "Additional keys that identify the target of the policies within the org unit, such as " +
+					"`app_id` for the policies of an app, or `printer_id` for the policies of a printer. Every " +
+					"policy must have the same `additional_target_key_names` in its schema, which are listed by " +
+					"the `googleworkspace_chrome_policy_schema` data source.",
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"policies": {
 				Description: "Policies to set for the org unit",
 				Type:        schema.TypeList,
@@ -56,6 +76,13 @@ func resourceChromePolicy() *schema.Resource {
 								),
 							},
 						},
+						"inherited": {
+							Description: "Whether the policy's value is inherited from a parent org unit, rather than " +
+								"set on the org unit. An inherited value isn't managed by this resource, so its " +
+								"`schema_values` are read as empty and the policy is set on the org unit on the next apply.",
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
 					},
 				},
 			},
@@ -66,139 +93,253 @@ func resourceChromePolicy() *schema.Resource {
 func resourceChromePolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*apiClient)
 
-	chromePolicyService, diags := client.NewChromePolicyService()
+	orgUnitId := strings.TrimPrefix(d.Get("org_unit_id").(string), "id:")
+
+	log.Printf("[DEBUG] Creating Chrome Policy for org:%s", orgUnitId)
+
+	policyTargetKey := chromePolicyTargetKey(d)
+
+	diags := createChromePolicies(ctx, d, client, policyTargetKey, modifyChromeOrgUnitPolicies)
 	if diags.HasError() {
 		return diags
 	}
 
-	chromePoliciesService, diags := GetChromePoliciesService(chromePolicyService)
+	log.Printf("[DEBUG] Finished creating Chrome Policy for org:%s", orgUnitId)
+	d.SetId(chromePolicyId(orgUnitId, policyTargetKey.AdditionalTargetKeys))
+
+	return resourceChromePolicyRead(ctx, d, meta)
+}
+
+func resourceChromePolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	log.Printf("[DEBUG] Updating Chrome Policy for org:%s", d.Id())
+
+	// Update is achieved by inheriting defaults for the previous policySchemas, and then applying the new set
+	old, _ := d.GetChange("policies")
+
+	diags := removeChromePolicies(ctx, client, chromePolicyTargetKey(d), old.([]interface{}), inheritChromeOrgUnitPolicies)
 	if diags.HasError() {
 		return diags
 	}
 
-	orgUnitId := strings.TrimPrefix(d.Get("org_unit_id").(string), "id:")
+	// run create
+	diags = resourceChromePolicyCreate(ctx, d, meta)
+	if diags.HasError() {
+		return diags
+	}
 
-	log.Printf("[DEBUG] Creating Chrome Policy for org:%s", orgUnitId)
+	log.Printf("[DEBUG] Finished Updating Chrome Policy for org:%s", d.Id())
 
-	policyTargetKey := &chromepolicy.GoogleChromePolicyV1PolicyTargetKey{
-		TargetResource: "orgunits/" + orgUnitId,
-	}
+	return diags
+}
+
+func resourceChromePolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
 
-	diags = validateChromePolicies(ctx, d, client)
+	log.Printf("[DEBUG] Getting Chrome Policy for org:%s", d.Id())
+
+	// a value that's inherited from a parent org unit isn't set here, so it's
+	// read as empty for the drift to show
+	policies, diags := readChromePolicies(ctx, d, client, chromePolicyTargetKey(d), true)
 	if diags.HasError() {
 		return diags
 	}
 
-	policies, diags := expandChromePoliciesValues(d.Get("policies").([]interface{}))
+	if err := d.Set("policies", policies); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Finished getting Chrome Policy for org:%s", d.Id())
+	return nil
+}
+
+func resourceChromePolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	log.Printf("[DEBUG] Deleting Chrome Policy for org:%s", d.Id())
+
+	diags := removeChromePolicies(ctx, client, chromePolicyTargetKey(d), d.Get("policies").([]interface{}), inheritChromeOrgUnitPolicies)
 	if diags.HasError() {
 		return diags
 	}
 
-	var requests []*chromepolicy.GoogleChromePolicyV1ModifyOrgUnitPolicyRequest
-	for _, p := range policies {
-		var keys []string
-		var schemaValues map[string]interface{}
-		if err := json.Unmarshal(p.Value, &schemaValues); err != nil {
-			return diag.FromErr(err)
-		}
-		for key := range schemaValues {
-			keys = append(keys, key)
-		}
-		requests = append(requests, &chromepolicy.GoogleChromePolicyV1ModifyOrgUnitPolicyRequest{
-			PolicyTargetKey: policyTargetKey,
-			PolicyValue:     p,
-			UpdateMask:      strings.Join(keys, ","),
-		})
+	log.Printf("[DEBUG] Finished deleting Chrome Policy for org:%s", d.Id())
+	return nil
+}
+
+// id is of format "<org_unit_id>/<policy_schema_filters>", optionally followed
+// by the additional target keys as an encoded query string. The filters are
+// comma-separated schema names or namespaces, such as "chrome.users.*".
+func resourceChromePolicyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*apiClient)
+
+	orgUnitId, filters, additionalTargetKeys, err := parseChromePolicyImportId(d.Id(), "<org_unit_id>/<policy_schema_filters>[?<additional_target_keys>]")
+	if err != nil {
+		return nil, err
 	}
 
-	err := retryTimeDuration(ctx, time.Minute, func() error {
-		_, retryErr := chromePoliciesService.Orgunits.BatchModify(fmt.Sprintf("customers/%s", client.Customer), &chromepolicy.GoogleChromePolicyV1BatchModifyOrgUnitPoliciesRequest{Requests: requests}).Do()
-		return retryErr
-	})
+	d.Set("org_unit_id", orgUnitId)
+	d.Set("additional_target_keys", additionalTargetKeys)
+	orgUnitId = strings.TrimPrefix(orgUnitId, "id:")
+
+	log.Printf("[DEBUG] Importing Chrome Policy for org:%s", orgUnitId)
 
+	// only the policies set directly on the org unit are imported, as those
+	// that are inherited are managed on the org unit they're set on
+	policies, err := importChromePolicies(ctx, client, chromePolicyTargetKey(d), filters)
 	if err != nil {
-		return diag.FromErr(err)
+		return nil, err
 	}
 
-	log.Printf("[DEBUG] Finished creating Chrome Policy for org:%s", orgUnitId)
-	d.SetId(orgUnitId)
+	if len(policies) == 0 {
+		return nil, fmt.Errorf("no policies matching %s are set on org unit %s", filters, orgUnitId)
+	}
 
-	return resourceChromePolicyRead(ctx, d, meta)
-}
+	if err := d.Set("policies", policies); err != nil {
+		return nil, err
+	}
 
-func resourceChromePolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*apiClient)
+	d.SetId(chromePolicyId(orgUnitId, additionalTargetKeys))
+
+	return []*schema.ResourceData{d}, nil
+}
 
+// modifyChromeOrgUnitPolicies sets the fields of the policies of an org unit
+// that are named by their update masks.
+func modifyChromeOrgUnitPolicies(ctx context.Context, client *apiClient, policyTargetKey *chromepolicy.GoogleChromePolicyV1PolicyTargetKey, policies []*chromepolicy.GoogleChromePolicyV1PolicyValue, updateMasks []string) error {
 	chromePolicyService, diags := client.NewChromePolicyService()
 	if diags.HasError() {
-		return diags
+		return fmt.Errorf("%s", diags[0].Summary)
 	}
 
 	chromePoliciesService, diags := GetChromePoliciesService(chromePolicyService)
 	if diags.HasError() {
-		return diags
+		return fmt.Errorf("%s", diags[0].Summary)
 	}
 
-	log.Printf("[DEBUG] Updating Chrome Policy for org:%s", d.Id())
+	var requests []*chromepolicy.GoogleChromePolicyV1ModifyOrgUnitPolicyRequest
+	for i, p := range policies {
+		requests = append(requests, &chromepolicy.GoogleChromePolicyV1ModifyOrgUnitPolicyRequest{
+			PolicyTargetKey: policyTargetKey,
+			PolicyValue:     p,
+			UpdateMask:      updateMasks[i],
+		})
+	}
 
-	policyTargetKey := &chromepolicy.GoogleChromePolicyV1PolicyTargetKey{
-		TargetResource: "orgunits/" + d.Id(),
+	_, err := chromePoliciesService.Orgunits.BatchModify(fmt.Sprintf("customers/%s", client.Customer), &chromepolicy.GoogleChromePolicyV1BatchModifyOrgUnitPoliciesRequest{Requests: requests}).Context(ctx).Do()
+	return err
+}
+
+// inheritChromeOrgUnitPolicies makes an org unit inherit the policies from its
+// parent.
+func inheritChromeOrgUnitPolicies(ctx context.Context, client *apiClient, policyTargetKey *chromepolicy.GoogleChromePolicyV1PolicyTargetKey, schemaNames []string) error {
+	chromePolicyService, diags := client.NewChromePolicyService()
+	if diags.HasError() {
+		return fmt.Errorf("%s", diags[0].Summary)
 	}
 
-	// Update is achieved by inheriting defaults for the previous policySchemas, and then applying the new set
-	old, _ := d.GetChange("policies")
+	chromePoliciesService, diags := GetChromePoliciesService(chromePolicyService)
+	if diags.HasError() {
+		return fmt.Errorf("%s", diags[0].Summary)
+	}
 
 	var requests []*chromepolicy.GoogleChromePolicyV1InheritOrgUnitPolicyRequest
-	for _, p := range old.([]interface{}) {
-		policy := p.(map[string]interface{})
-		schemaName := policy["schema_name"].(string)
-
+	for _, schemaName := range schemaNames {
 		requests = append(requests, &chromepolicy.GoogleChromePolicyV1InheritOrgUnitPolicyRequest{
 			PolicyTargetKey: policyTargetKey,
 			PolicySchema:    schemaName,
 		})
 	}
 
-	err := retryTimeDuration(ctx, time.Minute, func() error {
-		_, retryErr := chromePoliciesService.Orgunits.BatchInherit(fmt.Sprintf("customers/%s", client.Customer), &chromepolicy.GoogleChromePolicyV1BatchInheritOrgUnitPoliciesRequest{Requests: requests}).Do()
-		return retryErr
-	})
+	_, err := chromePoliciesService.Orgunits.BatchInherit(fmt.Sprintf("customers/%s", client.Customer), &chromepolicy.GoogleChromePolicyV1BatchInheritOrgUnitPoliciesRequest{Requests: requests}).Context(ctx).Do()
+	return err
+}
 
-	if err != nil {
-		return diag.FromErr(err)
+// The policies of org units and of groups are set and read the same way, but
+// with different targets and with different calls to set and remove them.
+
+// chromePolicyModifyFunc sets the fields of the policies on the target that
+// are named by their update masks.
+type chromePolicyModifyFunc func(ctx context.Context, client *apiClient, policyTargetKey *chromepolicy.GoogleChromePolicyV1PolicyTargetKey, policies []*chromepolicy.GoogleChromePolicyV1PolicyValue, updateMasks []string) error
+
+// chromePolicyRemoveFunc removes the policies from the target, by inheriting
+// them for an org unit, or by deleting them for a group.
+type chromePolicyRemoveFunc func(ctx context.Context, client *apiClient, policyTargetKey *chromepolicy.GoogleChromePolicyV1PolicyTargetKey, schemaNames []string) error
+
+// createChromePolicies validates the configured policies, and sets them on the
+// target.
+func createChromePolicies(ctx context.Context, d *schema.ResourceData, client *apiClient, policyTargetKey *chromepolicy.GoogleChromePolicyV1PolicyTargetKey, modify chromePolicyModifyFunc) diag.Diagnostics {
+	diags := validateChromePolicies(ctx, d, client)
+	if diags.HasError() {
+		return diags
 	}
 
-	// run create
-	diags = resourceChromePolicyCreate(ctx, d, meta)
+	policies, diags := expandChromePoliciesValues(d.Get("policies").([]interface{}))
 	if diags.HasError() {
 		return diags
 	}
 
-	log.Printf("[DEBUG] Finished Updating Chrome Policy for org:%s", d.Id())
+	var updateMasks []string
+	for _, p := range policies {
+		var keys []string
+		var schemaValues map[string]interface{}
+		if err := json.Unmarshal(p.Value, &schemaValues); err != nil {
+			return diag.FromErr(err)
+		}
+		for key := range schemaValues {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
 
-	return diags
+		updateMasks = append(updateMasks, strings.Join(keys, ","))
+	}
+
+	err := retryTimeDuration(ctx, time.Minute, func() error {
+		return modify(ctx, client, policyTargetKey, policies, updateMasks)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
 }
 
-func resourceChromePolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*apiClient)
+// removeChromePolicies removes the policies, which are of the schema of the
+// resource, from the target.
+func removeChromePolicies(ctx context.Context, client *apiClient, policyTargetKey *chromepolicy.GoogleChromePolicyV1PolicyTargetKey, policies []interface{}, remove chromePolicyRemoveFunc) diag.Diagnostics {
+	var schemaNames []string
+	for _, p := range policies {
+		policy := p.(map[string]interface{})
+		schemaNames = append(schemaNames, policy["schema_name"].(string))
+	}
+
+	err := retryTimeDuration(ctx, time.Minute, func() error {
+		return remove(ctx, client, policyTargetKey, schemaNames)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
 
+// readChromePolicies resolves each of the configured policies on the target.
+// A policy that isn't set on the target is read as empty for the drift to
+// show. When the target inherits policies, each policy is also read with
+// whether its value is inherited.
+func readChromePolicies(ctx context.Context, d *schema.ResourceData, client *apiClient, policyTargetKey *chromepolicy.GoogleChromePolicyV1PolicyTargetKey, inherits bool) ([]map[string]interface{}, diag.Diagnostics) {
 	chromePolicyService, diags := client.NewChromePolicyService()
 	if diags.HasError() {
-		return diags
+		return nil, diags
 	}
 
 	chromePoliciesService, diags := GetChromePoliciesService(chromePolicyService)
 	if diags.HasError() {
-		return diags
-	}
-
-	log.Printf("[DEBUG] Getting Chrome Policy for org:%s", d.Id())
-
-	policyTargetKey := &chromepolicy.GoogleChromePolicyV1PolicyTargetKey{
-		TargetResource: "orgunits/" + d.Id(),
+		return nil, diags
 	}
 
-	policiesObj := []*chromepolicy.GoogleChromePolicyV1PolicyValue{}
+	var policies []map[string]interface{}
 	for _, p := range d.Get("policies").([]interface{}) {
 		policy := p.(map[string]interface{})
 		schemaName := policy["schema_name"].(string)
@@ -207,81 +348,127 @@ func resourceChromePolicyRead(ctx context.Context, d *schema.ResourceData, meta
 		err := retryTimeDuration(ctx, time.Minute, func() error {
 			var retryErr error
 
-			// we will resolve each individual policySchema by fully qualified name, so the responses should be a single result
+			// we will resolve each individual policySchema by fully qualified name, so the responses should be at most a single result
 			resp, retryErr = chromePoliciesService.Resolve(fmt.Sprintf("customers/%s", client.Customer), &chromepolicy.GoogleChromePolicyV1ResolveRequest{
 				PolicySchemaFilter: schemaName,
 				PolicyTargetKey:    policyTargetKey,
-			}).Do()
+			}).Context(ctx).Do()
 
 			return retryErr
 		})
 		if err != nil {
-			return diag.FromErr(err)
+			return nil, diag.FromErr(err)
 		}
 
-		if len(resp.ResolvedPolicies) != 1 {
-			return diag.Errorf("unexpected number of resolved policies for schema: %s", schemaName)
+		if len(resp.ResolvedPolicies) > 1 {
+			return nil, diag.Errorf("unexpected number of resolved policies for schema: %s", schemaName)
 		}
 
-		value := resp.ResolvedPolicies[0].Value
+		if len(resp.ResolvedPolicies) == 0 || !chromePolicyIsSetOnTarget(resp.ResolvedPolicies[0], policyTargetKey) {
+			inherited := len(resp.ResolvedPolicies) == 1
+			log.Printf("[DEBUG] Chrome Policy %s is not set on %s (inherited: %t)", schemaName, policyTargetKey.TargetResource, inherited)
 
-		policiesObj = append(policiesObj, value)
-	}
+			unset := map[string]interface{}{
+				"schema_name":   schemaName,
+				"schema_values": map[string]interface{}{},
+			}
+			if inherits {
+				unset["inherited"] = inherited
+			}
 
-	policies, diags := flattenChromePolicies(ctx, policiesObj, client)
-	if diags.HasError() {
-		return diags
-	}
+			policies = append(policies, unset)
+			continue
+		}
 
-	if err := d.Set("policies", policies); err != nil {
-		return diag.FromErr(err)
+		flattened, diags := flattenChromePolicies(ctx, []*chromepolicy.GoogleChromePolicyV1PolicyValue{resp.ResolvedPolicies[0].Value}, client)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		if inherits {
+			flattened[0]["inherited"] = false
+		}
+		policies = append(policies, flattened[0])
 	}
 
-	log.Printf("[DEBUG] Finished getting Chrome Policy for org:%s", d.Id())
-	return nil
+	return policies, nil
 }
 
-func resourceChromePolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*apiClient)
-
+// importChromePolicies resolves the policies that match the comma-separated
+// filters, and that are set directly on the target, sorted by schema name.
+func importChromePolicies(ctx context.Context, client *apiClient, policyTargetKey *chromepolicy.GoogleChromePolicyV1PolicyTargetKey, filters string) ([]map[string]interface{}, error) {
 	chromePolicyService, diags := client.NewChromePolicyService()
 	if diags.HasError() {
-		return diags
+		return nil, fmt.Errorf("%s", diags[0].Summary)
 	}
 
 	chromePoliciesService, diags := GetChromePoliciesService(chromePolicyService)
 	if diags.HasError() {
-		return diags
+		return nil, fmt.Errorf("%s", diags[0].Summary)
 	}
 
-	log.Printf("[DEBUG] Deleting Chrome Policy for org:%s", d.Id())
-
-	policyTargetKey := &chromepolicy.GoogleChromePolicyV1PolicyTargetKey{
-		TargetResource: "orgunits/" + d.Id(),
-	}
+	policiesObj := []*chromepolicy.GoogleChromePolicyV1PolicyValue{}
+	for _, filter := range strings.Split(filters, ",") {
+		err := chromePoliciesService.Resolve(fmt.Sprintf("customers/%s", client.Customer), &chromepolicy.GoogleChromePolicyV1ResolveRequest{
+			PolicySchemaFilter: strings.TrimSpace(filter),
+			PolicyTargetKey:    policyTargetKey,
+		}).Pages(ctx, func(resp *chromepolicy.GoogleChromePolicyV1ResolveResponse) error {
+			for _, resolved := range resp.ResolvedPolicies {
+				if !chromePolicyIsSetOnTarget(resolved, policyTargetKey) {
+					continue
+				}
 
-	var requests []*chromepolicy.GoogleChromePolicyV1InheritOrgUnitPolicyRequest
-	for _, p := range d.Get("policies").([]interface{}) {
-		policy := p.(map[string]interface{})
-		schemaName := policy["schema_name"].(string)
+				policiesObj = append(policiesObj, resolved.Value)
+			}
 
-		requests = append(requests, &chromepolicy.GoogleChromePolicyV1InheritOrgUnitPolicyRequest{
-			PolicyTargetKey: policyTargetKey,
-			PolicySchema:    schemaName,
+			return nil
 		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	err := retryTimeDuration(ctx, time.Minute, func() error {
-		_, retryErr := chromePoliciesService.Orgunits.BatchInherit(fmt.Sprintf("customers/%s", client.Customer), &chromepolicy.GoogleChromePolicyV1BatchInheritOrgUnitPoliciesRequest{Requests: requests}).Do()
-		return retryErr
+	if len(policiesObj) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(policiesObj, func(i, j int) bool {
+		return policiesObj[i].PolicySchema < policiesObj[j].PolicySchema
 	})
 
+	policies, diags := flattenChromePolicies(ctx, policiesObj, client)
+	if diags.HasError() {
+		return nil, fmt.Errorf("%s", diags[0].Summary)
+	}
+
+	return policies, nil
+}
+
+// parseChromePolicyImportId splits the import id into the org unit ID, the
+// policy schemas, and the additional target keys that are encoded as a query
+// string after them.
+func parseChromePolicyImportId(id, format string) (string, string, map[string]string, error) {
+	path, query := id, ""
+	if i := strings.Index(path, "?"); i >= 0 {
+		path, query = path[:i], path[i+1:]
+	}
+
+	parts := strings.SplitN(path, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", nil, fmt.Errorf("Chrome Policy Id (%s) is not of the correct format (%s)", id, format)
+	}
+
+	values, err := url.ParseQuery(query)
 	if err != nil {
-		return diag.FromErr(err)
+		return "", "", nil, fmt.Errorf("Chrome Policy Id (%s) has invalid additional target keys: %s", id, err)
 	}
 
-	log.Printf("[DEBUG] Finished deleting Chrome Policy for org:%s", d.Id())
-	return nil
+	additionalTargetKeys := map[string]string{}
+	for k := range values {
+		additionalTargetKeys[k] = values.Get(k)
+	}
+
+	return parts[0], parts[1], additionalTargetKeys, nil
 }
 
 // Chrome Policies
@@ -304,69 +491,115 @@ func validateChromePolicies(ctx context.Context, d *schema.ResourceData, client
 	// Validate config against schemas
 	for _, policy := range new.([]interface{}) {
 		schemaName := policy.(map[string]interface{})["schema_name"].(string)
+		schemaValues := policy.(map[string]interface{})["schema_values"].(map[string]interface{})
 
-		var schemaDef *chromepolicy.GoogleChromePolicyV1PolicySchema
-		err := retryTimeDuration(ctx, time.Minute, func() error {
-			var retryErr error
+		diags = validateChromePolicy(ctx, chromePolicySchemasService, client, schemaName, schemaValues,
+			d.Get("additional_target_keys").(map[string]interface{}))
+		if diags.HasError() {
+			return diags
+		}
+	}
 
-			schemaDef, retryErr = chromePolicySchemasService.Get(fmt.Sprintf("customers/%s/policySchemas/%s", client.Customer, schemaName)).Do()
-			return retryErr
+	return nil
+}
+
+// validateChromePolicy validates the values and the additional target keys of
+// a policy against its schema.
+func validateChromePolicy(ctx context.Context, chromePolicySchemasService *chromepolicy.CustomersPolicySchemasService, client *apiClient, schemaName string, schemaValues, additionalTargetKeys map[string]interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var schemaDef *chromepolicy.GoogleChromePolicyV1PolicySchema
+	err := retryTimeDuration(ctx, time.Minute, func() error {
+		var retryErr error
+
+		schemaDef, retryErr = chromePolicySchemasService.Get(fmt.Sprintf("customers/%s/policySchemas/%s", client.Customer, schemaName)).Context(ctx).Do()
+		return retryErr
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if schemaDef == nil || schemaDef.Definition == nil || schemaDef.Definition.MessageType == nil {
+		return append(diags, diag.Diagnostic{
+			Summary:  fmt.Sprintf("schema definition (%s) is empty", schemaName),
+			Severity: diag.Error,
 		})
-		if err != nil {
-			return diag.FromErr(err)
+	}
+
+	diags = validateChromePolicyAdditionalTargetKeys(schemaName, schemaDef.AdditionalTargetKeyNames, additionalTargetKeys)
+	if diags.HasError() {
+		return diags
+	}
+
+	schemaFieldMap := map[string][]*chromepolicy.Proto2FieldDescriptorProto{}
+	for _, schemaField := range schemaDef.Definition.MessageType {
+		for _, schemaNestedField := range schemaField.Field {
+			schemaFieldMap[schemaNestedField.Name] = schemaField.Field
 		}
+	}
 
-		if schemaDef == nil || schemaDef.Definition == nil || schemaDef.Definition.MessageType == nil {
+	for polKey, polJsonVal := range schemaValues {
+		if _, ok := schemaFieldMap[polKey]; !ok {
 			return append(diags, diag.Diagnostic{
-				Summary:  fmt.Sprintf("schema definition (%s) is empty", schemaName),
+				Summary:  fmt.Sprintf("field name (%s) is not found in this schema definition (%s)", polKey, schemaName),
 				Severity: diag.Error,
 			})
 		}
 
-		schemaFieldMap := map[string][]*chromepolicy.Proto2FieldDescriptorProto{}
-		for _, schemaField := range schemaDef.Definition.MessageType {
-			for _, schemaNestedField := range schemaField.Field {
-				schemaFieldMap[schemaNestedField.Name] = schemaField.Field
-			}
+		var polVal interface{}
+		err := json.Unmarshal([]byte(polJsonVal.(string)), &polVal)
+		if err != nil {
+			return diag.FromErr(err)
 		}
 
-		policyDef := policy.(map[string]interface{})["schema_values"].(map[string]interface{})
+		for _, schemaField := range schemaFieldMap[polKey] {
 
-		for polKey, polJsonVal := range policyDef {
-			if _, ok := schemaFieldMap[polKey]; !ok {
+			if schemaField == nil {
 				return append(diags, diag.Diagnostic{
-					Summary:  fmt.Sprintf("field name (%s) is not found in this schema definition (%s)", polKey, schemaName),
-					Severity: diag.Error,
+					Summary:  fmt.Sprintf("field type is not defined for field name (%s)", polKey),
+					Severity: diag.Warning,
 				})
 			}
 
-			var polVal interface{}
-			err := json.Unmarshal([]byte(polJsonVal.(string)), &polVal)
-			if err != nil {
-				return diag.FromErr(err)
+			validType := validatePolicyFieldValueType(schemaField.Type, polVal)
+			if !validType {
+				return append(diags, diag.Diagnostic{
+					Summary:  fmt.Sprintf("value provided for %s is of incorrect type (expected type: %s)", schemaField.Name, schemaField.Type),
+					Severity: diag.Error,
+				})
 			}
+		}
+	}
 
-			for _, schemaField := range schemaFieldMap[polKey] {
+	return nil
+}
 
-				if schemaField == nil {
-					return append(diags, diag.Diagnostic{
-						Summary:  fmt.Sprintf("field type is not defined for field name (%s)", polKey),
-						Severity: diag.Warning,
-					})
-				}
+// The additional target keys have to be exactly those named by the schema
+func validateChromePolicyAdditionalTargetKeys(schemaName string, keyNames []*chromepolicy.GoogleChromePolicyV1AdditionalTargetKeyName, additionalTargetKeys map[string]interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-				validType := validatePolicyFieldValueType(schemaField.Type, polVal)
-				if !validType {
-					return append(diags, diag.Diagnostic{
-						Summary:  fmt.Sprintf("value provided for %s is of incorrect type (expected type: %s)", schemaField.Name, schemaField.Type),
-						Severity: diag.Error,
-					})
-				}
-			}
+	names := map[string]bool{}
+	for _, keyName := range keyNames {
+		names[keyName.Key] = true
+
+		if _, ok := additionalTargetKeys[keyName.Key]; !ok {
+			diags = append(diags, diag.Diagnostic{
+				Summary:  fmt.Sprintf("additional target key (%s) is required by this schema definition (%s)", keyName.Key, schemaName),
+				Severity: diag.Error,
+			})
 		}
 	}
 
-	return nil
+	for key := range additionalTargetKeys {
+		if !names[key] {
+			diags = append(diags, diag.Diagnostic{
+				Summary:  fmt.Sprintf("additional target key (%s) is not found in this schema definition (%s)", key, schemaName),
+				Severity: diag.Error,
+			})
+		}
+	}
+
+	return diags
 }
 
 // This will take a value and validate whether the type is correct
@@ -529,7 +762,7 @@ func flattenChromePolicies(ctx context.Context, policiesObj []*chromepolicy.Goog
 		err := retryTimeDuration(ctx, time.Minute, func() error {
 			var retryErr error
 
-			schemaDef, retryErr = schemaService.Get(fmt.Sprintf("customers/%s/policySchemas/%s", client.Customer, polObj.PolicySchema)).Do()
+			schemaDef, retryErr = schemaService.Get(fmt.Sprintf("customers/%s/policySchemas/%s", client.Customer, polObj.PolicySchema)).Context(ctx).Do()
 			return retryErr
 		})
 		if err != nil {
@@ -596,3 +829,45 @@ func flattenChromePolicies(ctx context.Context, policiesObj []*chromepolicy.Goog
 
 	return policies, nil
 }
+
+// chromePolicyTargetKey is the target of the policies, which is the org unit,
+// and the app or printer within it that's identified by the additional target
+// keys.
+func chromePolicyTargetKey(d *schema.ResourceData) *chromepolicy.GoogleChromePolicyV1PolicyTargetKey {
+	orgUnitId := strings.TrimPrefix(d.Get("org_unit_id").(string), "id:")
+
+	return &chromepolicy.GoogleChromePolicyV1PolicyTargetKey{
+		TargetResource:       "orgunits/" + orgUnitId,
+		AdditionalTargetKeys: expandChromePolicyAdditionalTargetKeys(d),
+	}
+}
+
+func expandChromePolicyAdditionalTargetKeys(d *schema.ResourceData) map[string]string {
+	additionalTargetKeys := map[string]string{}
+	for k, v := range d.Get("additional_target_keys").(map[string]interface{}) {
+		additionalTargetKeys[k] = v.(string)
+	}
+
+	return additionalTargetKeys
+}
+
+// chromePolicyIsSetOnTarget is whether the resolved policy is set directly on
+// the target, rather than inherited from a parent org unit.
+func chromePolicyIsSetOnTarget(resolved *chromepolicy.GoogleChromePolicyV1ResolvedPolicy, policyTargetKey *chromepolicy.GoogleChromePolicyV1PolicyTargetKey) bool {
+	return resolved.SourceKey != nil && resolved.SourceKey.TargetResource == policyTargetKey.TargetResource
+}
+
+// chromePolicyId is the org unit ID, followed by the additional target keys
+// as an encoded query string, as in "<org_unit_id>?app_id=chrome%3Aabc".
+func chromePolicyId(orgUnitId string, additionalTargetKeys map[string]string) string {
+	if len(additionalTargetKeys) == 0 {
+		return orgUnitId
+	}
+
+	values := url.Values{}
+	for k, v := range additionalTargetKeys {
+		values.Set(k, v)
+	}
+
+	return orgUnitId + "?" + values.Encode()
+}
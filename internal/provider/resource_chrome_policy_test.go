@@ -4,10 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"google.golang.org/api/chromepolicy/v1"
@@ -16,11 +16,11 @@ import (
 func TestAccResourceChromePolicy_basic(t *testing.T) {
 	t.Parallel()
 
-	ouName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	ouName := fmt.Sprintf("tf-test-%s", randString(t, 10))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceChromePolicy_basic(ouName, 33),
@@ -28,8 +28,15 @@ func TestAccResourceChromePolicy_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("googleworkspace_chrome_policy.test", "policies.#", "1"),
 					resource.TestCheckResourceAttr("googleworkspace_chrome_policy.test", "policies.0.schema_name", "chrome.users.MaxConnectionsPerProxy"),
 					resource.TestCheckResourceAttr("googleworkspace_chrome_policy.test", "policies.0.schema_values.maxConnectionsPerProxy", "33"),
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy.test", "policies.0.inherited", "false"),
 				),
 			},
+			{
+				ResourceName:      "googleworkspace_chrome_policy.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccResourceChromePolicyImportStateIdFunc("googleworkspace_chrome_policy.test", "chrome.users.*"),
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -37,11 +44,11 @@ func TestAccResourceChromePolicy_basic(t *testing.T) {
 func TestAccResourceChromePolicy_typeMessage(t *testing.T) {
 	t.Parallel()
 
-	ouName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	ouName := fmt.Sprintf("tf-test-%s", randString(t, 10))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceChromePolicy_typeMessage(ouName),
@@ -58,11 +65,11 @@ func TestAccResourceChromePolicy_typeMessage(t *testing.T) {
 func TestAccResourceChromePolicy_update(t *testing.T) {
 	t.Parallel()
 
-	ouName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	ouName := fmt.Sprintf("tf-test-%s", randString(t, 10))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceChromePolicy_basic(ouName, 33),
@@ -87,13 +94,13 @@ func TestAccResourceChromePolicy_update(t *testing.T) {
 func TestAccResourceChromePolicy_multiple(t *testing.T) {
 	t.Parallel()
 
-	ouName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	ouName := fmt.Sprintf("tf-test-%s", randString(t, 10))
 
 	// ensures previously set field was reset/removed
 	// this passing also implies Delete works correctly
 	// based on the implementation
 	testCheck := func(s *terraform.State) error {
-		client, err := googleworkspaceTestClient()
+		client, err := testAccClient(t)
 		if err != nil {
 			return err
 		}
@@ -136,7 +143,7 @@ func TestAccResourceChromePolicy_multiple(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceChromePolicy_multiple(ouName, 33, ".*@example"),
@@ -173,6 +180,129 @@ func TestAccResourceChromePolicy_multiple(t *testing.T) {
 	})
 }
 
+func TestAccResourceChromePolicy_inherited(t *testing.T) {
+	t.Parallel()
+
+	ouName := fmt.Sprintf("tf-test-%s", randString(t, 10))
+
+	var orgUnitId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceChromePolicy_inherited(ouName, 33),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy.test", "policies.0.inherited", "false"),
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy.test", "policies.0.schema_values.maxConnectionsPerProxy", "33"),
+					func(s *terraform.State) error {
+						orgUnitId = s.RootModule().Resources["googleworkspace_chrome_policy.test"].Primary.Attributes["org_unit_id"]
+						return nil
+					},
+				),
+			},
+			{
+				// the child's value is removed, so it inherits the same value
+				// from the parent, which is still drift
+				PreConfig:          func() { testAccInheritChromePolicy(t, orgUnitId, "chrome.users.MaxConnectionsPerProxy") },
+				Config:             testAccResourceChromePolicy_inherited(ouName, 33),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceChromePolicy_additionalTargetKeys(t *testing.T) {
+	t.Parallel()
+
+	ouName := fmt.Sprintf("tf-test-%s", randString(t, 10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceChromePolicy_additionalTargetKeys(ouName, "APP_INSTALL_TYPE_ENUM_FORCED"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy.test", "additional_target_keys.app_id", "chrome:aapbdbdomjkkjkaonfhkkikfgjllcleb"),
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy.test", "policies.#", "1"),
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy.test", "policies.0.schema_name", "chrome.users.apps.InstallType"),
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy.test", "policies.0.schema_values.appInstallType", encode("APP_INSTALL_TYPE_ENUM_FORCED")),
+				),
+			},
+			{
+				Config: testAccResourceChromePolicy_additionalTargetKeys(ouName, "APP_INSTALL_TYPE_ENUM_BLOCKED"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy.test", "policies.0.schema_values.appInstallType", encode("APP_INSTALL_TYPE_ENUM_BLOCKED")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceChromePolicy_additionalTargetKeysMissing(t *testing.T) {
+	t.Parallel()
+
+	ouName := fmt.Sprintf("tf-test-%s", randString(t, 10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceChromePolicy_additionalTargetKeysMissing(ouName),
+				ExpectError: regexp.MustCompile("additional target key \\(app_id\\) is required"),
+			},
+		},
+	})
+}
+
+func testAccInheritChromePolicy(t *testing.T, orgUnitId, schemaName string) {
+	client, err := testAccClient(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chromePolicyService, diags := client.NewChromePolicyService()
+	if diags.HasError() {
+		t.Fatalf("Error creating chrome policy service %+v", diags)
+	}
+
+	chromePoliciesService, diags := GetChromePoliciesService(chromePolicyService)
+	if diags.HasError() {
+		t.Fatalf("Error getting chrome policies service %+v", diags)
+	}
+
+	_, err = chromePoliciesService.Orgunits.BatchInherit(fmt.Sprintf("customers/%s", client.Customer), &chromepolicy.GoogleChromePolicyV1BatchInheritOrgUnitPoliciesRequest{
+		Requests: []*chromepolicy.GoogleChromePolicyV1InheritOrgUnitPolicyRequest{
+			{
+				PolicyTargetKey: &chromepolicy.GoogleChromePolicyV1PolicyTargetKey{
+					TargetResource: "orgunits/" + strings.TrimPrefix(orgUnitId, "id:"),
+				},
+				PolicySchema: schemaName,
+			},
+		},
+	}).Do()
+	if err != nil {
+		t.Fatalf("Error inheriting chrome policy %s on org unit %s: %s", schemaName, orgUnitId, err)
+	}
+}
+
+// testAccResourceChromePolicyImportStateIdFunc builds the import id of the
+// Chrome policies of the resource's org unit that match the filters.
+func testAccResourceChromePolicyImportStateIdFunc(resourceName, filters string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["org_unit_id"], filters), nil
+	}
+}
+
 func encode(content string) string {
 	res, _ := json.Marshal(content)
 	return string(res)
@@ -203,6 +333,42 @@ resource "googleworkspace_chrome_policy" "test" {
 `, ouName, pattern, conns)
 }
 
+func testAccResourceChromePolicy_inherited(ouName string, conns int) string {
+	return fmt.Sprintf(`
+resource "googleworkspace_org_unit" "parent" {
+  name = "%[1]s"
+  parent_org_unit_path = "/"
+}
+
+resource "googleworkspace_org_unit" "test" {
+  name = "%[1]s-child"
+  parent_org_unit_path = googleworkspace_org_unit.parent.org_unit_path
+}
+
+resource "googleworkspace_chrome_policy" "parent" {
+  org_unit_id = googleworkspace_org_unit.parent.id
+  policies {
+    schema_name = "chrome.users.MaxConnectionsPerProxy"
+    schema_values = {
+      maxConnectionsPerProxy = jsonencode(%[2]d)
+    }
+  }
+}
+
+resource "googleworkspace_chrome_policy" "test" {
+  org_unit_id = googleworkspace_org_unit.test.id
+  policies {
+    schema_name = "chrome.users.MaxConnectionsPerProxy"
+    schema_values = {
+      maxConnectionsPerProxy = jsonencode(%[2]d)
+    }
+  }
+
+  depends_on = [googleworkspace_chrome_policy.parent]
+}
+`, ouName, conns)
+}
+
 func testAccResourceChromePolicy_multipleRearranged(ouName string, conns int, pattern string) string {
 	return fmt.Sprintf(`
 resource "googleworkspace_org_unit" "test" {
@@ -290,3 +456,44 @@ resource "googleworkspace_chrome_policy" "test" {
 }
 `, ouName)
 }
+
+func testAccResourceChromePolicy_additionalTargetKeys(ouName, installType string) string {
+	return fmt.Sprintf(`
+resource "googleworkspace_org_unit" "test" {
+  name = "%s"
+  parent_org_unit_path = "/"
+}
+
+resource "googleworkspace_chrome_policy" "test" {
+  org_unit_id = googleworkspace_org_unit.test.id
+  additional_target_keys = {
+    "app_id" = "chrome:aapbdbdomjkkjkaonfhkkikfgjllcleb"
+  }
+  policies {
+    schema_name = "chrome.users.apps.InstallType"
+    schema_values = {
+      appInstallType = jsonencode("%s")
+    }
+  }
+}
+`, ouName, installType)
+}
+
+func testAccResourceChromePolicy_additionalTargetKeysMissing(ouName string) string {
+	return fmt.Sprintf(`
+resource "googleworkspace_org_unit" "test" {
+  name = "%s"
+  parent_org_unit_path = "/"
+}
+
+resource "googleworkspace_chrome_policy" "test" {
+  org_unit_id = googleworkspace_org_unit.test.id
+  policies {
+    schema_name = "chrome.users.apps.InstallType"
+    schema_values = {
+      appInstallType = jsonencode("APP_INSTALL_TYPE_ENUM_FORCED")
+    }
+  }
+}
+`, ouName)
+}
@@ -0,0 +1,407 @@
+package googleworkspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/api/chromepolicy/v1"
+)
+
+func resourceChromePolicyValue() *schema.Resource {
+	return &schema.Resource{
+		Description: "Chrome Policy Value resource in the Terraform Googleworkspace provider. Chrome Policy Value " +
+			"manages the fields of a single policy schema on an org unit, so separate configurations can manage " +
+			"different policies of the same org unit. A policy schema shouldn't also be managed by a " +
+			"`googleworkspace_chrome_policy` on the same org unit.",
+
+		CreateContext: resourceChromePolicyValueCreate,
+		UpdateContext: resourceChromePolicyValueUpdate,
+		ReadContext:   resourceChromePolicyValueRead,
+		DeleteContext: resourceChromePolicyValueDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceChromePolicyValueImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org_unit_id": {
+				Description:      "The target org unit on which this policy is applied.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: diffSuppressOrgUnitId,
+			},
+			"schema_name": {
+				Description: "The full qualified name of the policy schema.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"additional_target_keys": {
+				Description: "Additional keys that identify the target of the policy within the org unit, such as " +
+					"`app_id` for the policies of an app, or `printer_id` for the policies of a printer, as listed " +
+					"in the `additional_target_key_names` of the schema.",
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"schema_values": {
+				Description: "JSON encoded map that represents key/value pairs that " +
+					"correspond to the given schema. Only these fields are managed: they're reset when removed, " +
+					"or when the resource is destroyed, and the other fields of the policy are left as they are. " +
+					"Once none of its fields are set, the policy is inherited from the parent org unit again.",
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(
+						validation.StringIsJSON,
+					),
+				},
+			},
+		},
+	}
+}
+
+func resourceChromePolicyValueCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	orgUnitId := strings.TrimPrefix(d.Get("org_unit_id").(string), "id:")
+	schemaName := d.Get("schema_name").(string)
+
+	log.Printf("[DEBUG] Creating Chrome Policy Value %s for org:%s", schemaName, orgUnitId)
+
+	policyTargetKey := chromePolicyTargetKey(d)
+
+	diags := resourceChromePolicyValueModify(ctx, d, client, policyTargetKey, nil)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished creating Chrome Policy Value %s for org:%s", schemaName, orgUnitId)
+	d.SetId(chromePolicyValueId(orgUnitId, schemaName, policyTargetKey.AdditionalTargetKeys))
+
+	return resourceChromePolicyValueRead(ctx, d, meta)
+}
+
+func resourceChromePolicyValueUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	log.Printf("[DEBUG] Updating Chrome Policy Value for org:%s", d.Id())
+
+	// the fields that were removed from the config are included in the update
+	// mask without a value, so they're reset
+	old, new := d.GetChange("schema_values")
+
+	var removed []string
+	for k := range old.(map[string]interface{}) {
+		if _, ok := new.(map[string]interface{})[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+
+	diags := resourceChromePolicyValueModify(ctx, d, client, chromePolicyTargetKey(d), removed)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished Updating Chrome Policy Value for org:%s", d.Id())
+
+	return resourceChromePolicyValueRead(ctx, d, meta)
+}
+
+func resourceChromePolicyValueRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	schemaName := d.Get("schema_name").(string)
+
+	log.Printf("[DEBUG] Getting Chrome Policy Value %s for org:%s", schemaName, d.Id())
+
+	schemaValues, diags := resolveChromePolicyValue(ctx, d, client)
+	if diags.HasError() {
+		return diags
+	}
+
+	// a value that's inherited from a parent org unit isn't set here
+	if schemaValues == nil {
+		log.Printf("[WARN] Chrome Policy Value %s is not set on org:%s, removing from state", schemaName, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	// only the fields managed by this resource are read, the other fields of
+	// the policy may be managed elsewhere
+	managed := d.Get("schema_values").(map[string]interface{})
+	for k := range schemaValues {
+		if _, ok := managed[k]; !ok {
+			delete(schemaValues, k)
+		}
+	}
+
+	if err := d.Set("schema_values", schemaValues); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Finished getting Chrome Policy Value %s for org:%s", schemaName, d.Id())
+	return nil
+}
+
+func resourceChromePolicyValueDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+
+	chromePolicyService, diags := client.NewChromePolicyService()
+	if diags.HasError() {
+		return diags
+	}
+
+	chromePoliciesService, diags := GetChromePoliciesService(chromePolicyService)
+	if diags.HasError() {
+		return diags
+	}
+
+	schemaName := d.Get("schema_name").(string)
+
+	log.Printf("[DEBUG] Deleting Chrome Policy Value %s for org:%s", schemaName, d.Id())
+
+	schemaValues, diags := resolveChromePolicyValue(ctx, d, client)
+	if diags.HasError() {
+		return diags
+	}
+
+	managed := d.Get("schema_values").(map[string]interface{})
+
+	var owned []string
+	unowned := 0
+	for k := range schemaValues {
+		if _, ok := managed[k]; ok {
+			owned = append(owned, k)
+		} else {
+			unowned++
+		}
+	}
+
+	// The fields of this resource are reset, and the fields that are managed
+	// elsewhere are left as they are. Once none are left, the whole policy is
+	// inherited again.
+	if unowned > 0 {
+		log.Printf("[DEBUG] Resetting fields %v of Chrome Policy Value %s for org:%s", owned, schemaName, d.Id())
+
+		diags = modifyChromePolicyValue(ctx, client, chromePolicyTargetKey(d), &chromepolicy.GoogleChromePolicyV1PolicyValue{
+			PolicySchema: schemaName,
+			Value:        []byte("{}"),
+		}, owned)
+		if diags.HasError() {
+			return diags
+		}
+	} else {
+		err := retryTimeDuration(ctx, time.Minute, func() error {
+			_, retryErr := chromePoliciesService.Orgunits.BatchInherit(fmt.Sprintf("customers/%s", client.Customer), &chromepolicy.GoogleChromePolicyV1BatchInheritOrgUnitPoliciesRequest{
+				Requests: []*chromepolicy.GoogleChromePolicyV1InheritOrgUnitPolicyRequest{
+					{
+						PolicyTargetKey: chromePolicyTargetKey(d),
+						PolicySchema:    schemaName,
+					},
+				},
+			}).Context(ctx).Do()
+			return retryErr
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("[DEBUG] Finished deleting Chrome Policy Value %s for org:%s", schemaName, d.Id())
+	return nil
+}
+
+// id is of format "<org_unit_id>/<schema_name>", optionally followed by the
+// additional target keys as an encoded query string.
+func resourceChromePolicyValueImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	orgUnitId, schemaName, additionalTargetKeys, err := parseChromePolicyImportId(d.Id(), "<org_unit_id>/<schema_name>[?<additional_target_keys>]")
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.ContainsAny(schemaName, "*,") {
+		return nil, fmt.Errorf("Chrome Policy Value Id (%s) must name a single policy schema", d.Id())
+	}
+
+	d.Set("org_unit_id", orgUnitId)
+	d.Set("schema_name", schemaName)
+	d.Set("additional_target_keys", additionalTargetKeys)
+
+	// every field that's set on the org unit is imported, as the fields
+	// managed by the resource are those that are in its state
+	schemaValues, diags := resolveChromePolicyValue(ctx, d, meta.(*apiClient))
+	if diags.HasError() {
+		return nil, fmt.Errorf("%s", diags[0].Summary)
+	}
+	if schemaValues == nil {
+		return nil, fmt.Errorf("Chrome Policy %s is not set on org unit %s", schemaName, orgUnitId)
+	}
+
+	if err := d.Set("schema_values", schemaValues); err != nil {
+		return nil, err
+	}
+
+	d.SetId(chromePolicyValueId(strings.TrimPrefix(orgUnitId, "id:"), schemaName, additionalTargetKeys))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceChromePolicyValueModify sets the configured fields of the policy,
+// and resets the other fields that are named by reset. The update mask only
+// has these fields, so the other fields of the policy are left as they are.
+func resourceChromePolicyValueModify(ctx context.Context, d *schema.ResourceData, client *apiClient, policyTargetKey *chromepolicy.GoogleChromePolicyV1PolicyTargetKey, reset []string) diag.Diagnostics {
+	chromePolicyService, diags := client.NewChromePolicyService()
+	if diags.HasError() {
+		return diags
+	}
+
+	chromePolicySchemasService, diags := GetChromePolicySchemasService(chromePolicyService)
+	if diags.HasError() {
+		return diags
+	}
+
+	schemaName := d.Get("schema_name").(string)
+	schemaValues := d.Get("schema_values").(map[string]interface{})
+
+	diags = validateChromePolicy(ctx, chromePolicySchemasService, client, schemaName, schemaValues,
+		d.Get("additional_target_keys").(map[string]interface{}))
+	if diags.HasError() {
+		return diags
+	}
+
+	policies, diags := expandChromePoliciesValues([]interface{}{
+		map[string]interface{}{
+			"schema_name":   schemaName,
+			"schema_values": schemaValues,
+		},
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	mask := map[string]bool{}
+	for _, k := range reset {
+		mask[k] = true
+	}
+
+	var schemaValuesObj map[string]interface{}
+	if err := json.Unmarshal(policies[0].Value, &schemaValuesObj); err != nil {
+		return diag.FromErr(err)
+	}
+	for k := range schemaValuesObj {
+		mask[k] = true
+	}
+
+	var keys []string
+	for k := range mask {
+		keys = append(keys, k)
+	}
+
+	return modifyChromePolicyValue(ctx, client, policyTargetKey, policies[0], keys)
+}
+
+// modifyChromePolicyValue sets the fields of the policy that are named by
+// keys, the fields that don't have a value are reset.
+func modifyChromePolicyValue(ctx context.Context, client *apiClient, policyTargetKey *chromepolicy.GoogleChromePolicyV1PolicyTargetKey, value *chromepolicy.GoogleChromePolicyV1PolicyValue, keys []string) diag.Diagnostics {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	chromePolicyService, diags := client.NewChromePolicyService()
+	if diags.HasError() {
+		return diags
+	}
+
+	chromePoliciesService, diags := GetChromePoliciesService(chromePolicyService)
+	if diags.HasError() {
+		return diags
+	}
+
+	sort.Strings(keys)
+
+	err := retryTimeDuration(ctx, time.Minute, func() error {
+		_, retryErr := chromePoliciesService.Orgunits.BatchModify(fmt.Sprintf("customers/%s", client.Customer), &chromepolicy.GoogleChromePolicyV1BatchModifyOrgUnitPoliciesRequest{
+			Requests: []*chromepolicy.GoogleChromePolicyV1ModifyOrgUnitPolicyRequest{
+				{
+					PolicyTargetKey: policyTargetKey,
+					PolicyValue:     value,
+					UpdateMask:      strings.Join(keys, ","),
+				},
+			},
+		}).Context(ctx).Do()
+		return retryErr
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resolveChromePolicyValue returns the fields of the policy that are set on
+// the target, or nil if the policy is inherited from a parent org unit, or
+// isn't set at all.
+func resolveChromePolicyValue(ctx context.Context, d *schema.ResourceData, client *apiClient) (map[string]interface{}, diag.Diagnostics) {
+	chromePolicyService, diags := client.NewChromePolicyService()
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	chromePoliciesService, diags := GetChromePoliciesService(chromePolicyService)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	schemaName := d.Get("schema_name").(string)
+	policyTargetKey := chromePolicyTargetKey(d)
+
+	var resp *chromepolicy.GoogleChromePolicyV1ResolveResponse
+	err := retryTimeDuration(ctx, time.Minute, func() error {
+		var retryErr error
+
+		resp, retryErr = chromePoliciesService.Resolve(fmt.Sprintf("customers/%s", client.Customer), &chromepolicy.GoogleChromePolicyV1ResolveRequest{
+			PolicySchemaFilter: schemaName,
+			PolicyTargetKey:    policyTargetKey,
+		}).Context(ctx).Do()
+
+		return retryErr
+	})
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	if len(resp.ResolvedPolicies) > 1 {
+		return nil, diag.Errorf("unexpected number of resolved policies for schema: %s", schemaName)
+	}
+
+	if len(resp.ResolvedPolicies) == 0 || !chromePolicyIsSetOnTarget(resp.ResolvedPolicies[0], policyTargetKey) {
+		return nil, diags
+	}
+
+	policies, diags := flattenChromePolicies(ctx, []*chromepolicy.GoogleChromePolicyV1PolicyValue{resp.ResolvedPolicies[0].Value}, client)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return policies[0]["schema_values"].(map[string]interface{}), diags
+}
+
+// chromePolicyValueId is the org unit ID and the schema name, followed by the
+// additional target keys as an encoded query string.
+func chromePolicyValueId(orgUnitId, schemaName string, additionalTargetKeys map[string]string) string {
+	return chromePolicyId(orgUnitId+"/"+schemaName, additionalTargetKeys)
+}
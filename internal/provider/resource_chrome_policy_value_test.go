@@ -0,0 +1,328 @@
+package googleworkspace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"google.golang.org/api/chromepolicy/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func TestAccResourceChromePolicyValue_basic(t *testing.T) {
+	t.Parallel()
+
+	ouName := fmt.Sprintf("tf-test-%s", randString(t, 10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceChromePolicyValue_basic(ouName, 33, "*@example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy_value.connections", "schema_name", "chrome.users.MaxConnectionsPerProxy"),
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy_value.connections", "schema_values.maxConnectionsPerProxy", "33"),
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy_value.signin", "schema_name", "chrome.users.RestrictSigninToPattern"),
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy_value.signin", "schema_values.restrictSigninToPattern", encode("*@example.com")),
+				),
+			},
+			{
+				ResourceName:      "googleworkspace_chrome_policy_value.connections",
+				ImportState:       true,
+				ImportStateIdFunc: testAccResourceChromePolicyValueImportStateIdFunc("googleworkspace_chrome_policy_value.connections"),
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccResourceChromePolicyValue_basic(ouName, 34, "*@example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy_value.connections", "schema_values.maxConnectionsPerProxy", "34"),
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy_value.signin", "schema_values.restrictSigninToPattern", encode("*@example.com")),
+				),
+			},
+			{
+				// removing one of the values leaves the other one set on the org unit
+				Config: testAccResourceChromePolicyValue_single(ouName, 34),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy_value.connections", "schema_values.maxConnectionsPerProxy", "34"),
+					testAccCheckChromePolicyValueFields(t, "googleworkspace_chrome_policy_value.connections", "chrome.users.RestrictSigninToPattern", nil),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceChromePolicyValue_unmanagedField(t *testing.T) {
+	t.Parallel()
+
+	ouName := fmt.Sprintf("tf-test-%s", randString(t, 10))
+
+	var orgUnitId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceChromePolicyValue_proxy(ouName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy_value.test", "schema_values.%", "2"),
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy_value.test", "schema_values.proxyMode", encode("PROXY_MODE_ENUM_FIXED_SERVERS")),
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy_value.test", "schema_values.proxyServer", encode("proxy.example.com:8080")),
+					func(s *terraform.State) error {
+						orgUnitId = s.RootModule().Resources["googleworkspace_chrome_policy_value.test"].Primary.Attributes["org_unit_id"]
+						return nil
+					},
+				),
+			},
+			{
+				// a field that's set outside of terraform isn't drift
+				PreConfig: func() {
+					testAccModifyChromePolicy(t, orgUnitId, "chrome.users.ProxySettings", `{"proxyPacUrl": "https://pac.example.com/proxy.pac"}`, "proxyPacUrl")
+				},
+				Config:   testAccResourceChromePolicyValue_proxy(ouName, true),
+				PlanOnly: true,
+			},
+			{
+				// removing a managed field leaves the unmanaged one set
+				Config: testAccResourceChromePolicyValue_proxy(ouName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy_value.test", "schema_values.%", "1"),
+					testAccCheckChromePolicyValueFields(t, "googleworkspace_chrome_policy_value.test", "chrome.users.ProxySettings", map[string]interface{}{
+						"proxyMode":   "PROXY_MODE_ENUM_FIXED_SERVERS",
+						"proxyPacUrl": "https://pac.example.com/proxy.pac",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceChromePolicyValue_additionalTargetKeys(t *testing.T) {
+	t.Parallel()
+
+	ouName := fmt.Sprintf("tf-test-%s", randString(t, 10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceChromePolicyValue_additionalTargetKeys(ouName, "APP_INSTALL_TYPE_ENUM_FORCED"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy_value.test", "additional_target_keys.app_id", "chrome:aapbdbdomjkkjkaonfhkkikfgjllcleb"),
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy_value.test", "schema_values.appInstallType", encode("APP_INSTALL_TYPE_ENUM_FORCED")),
+				),
+			},
+			{
+				ResourceName:      "googleworkspace_chrome_policy_value.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccResourceChromePolicyValueImportStateIdFunc("googleworkspace_chrome_policy_value.test"),
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccResourceChromePolicyValue_additionalTargetKeys(ouName, "APP_INSTALL_TYPE_ENUM_BLOCKED"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_chrome_policy_value.test", "schema_values.appInstallType", encode("APP_INSTALL_TYPE_ENUM_BLOCKED")),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckChromePolicyValueFields checks the fields of the policy that are
+// set on the org unit of the resource, nil expects the policy to be inherited.
+func testAccCheckChromePolicyValueFields(t *testing.T, resourceName, schemaName string, expected map[string]interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		client, err := testAccClient(t)
+		if err != nil {
+			return err
+		}
+
+		chromePolicyService, diags := client.NewChromePolicyService()
+		if diags.HasError() {
+			return errors.New(diags[0].Summary)
+		}
+
+		chromePoliciesService, diags := GetChromePoliciesService(chromePolicyService)
+		if diags.HasError() {
+			return errors.New(diags[0].Summary)
+		}
+
+		policyTargetKey := &chromepolicy.GoogleChromePolicyV1PolicyTargetKey{
+			TargetResource: "orgunits/" + strings.TrimPrefix(rs.Primary.Attributes["org_unit_id"], "id:"),
+		}
+
+		resp, err := chromePoliciesService.Resolve(fmt.Sprintf("customers/%s", client.Customer), &chromepolicy.GoogleChromePolicyV1ResolveRequest{
+			PolicySchemaFilter: schemaName,
+			PolicyTargetKey:    policyTargetKey,
+		}).Do()
+		if err != nil {
+			return err
+		}
+
+		var value map[string]interface{}
+		if len(resp.ResolvedPolicies) > 0 && chromePolicyIsSetOnTarget(resp.ResolvedPolicies[0], policyTargetKey) {
+			if err := json.Unmarshal(resp.ResolvedPolicies[0].Value.Value, &value); err != nil {
+				return err
+			}
+		}
+
+		if !reflect.DeepEqual(value, expected) {
+			return fmt.Errorf("expected %s to be %v on the org unit, got %v", schemaName, expected, value)
+		}
+
+		return nil
+	}
+}
+
+func testAccModifyChromePolicy(t *testing.T, orgUnitId, schemaName, value, updateMask string) {
+	client, err := testAccClient(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chromePolicyService, diags := client.NewChromePolicyService()
+	if diags.HasError() {
+		t.Fatalf("Error creating chrome policy service %+v", diags)
+	}
+
+	chromePoliciesService, diags := GetChromePoliciesService(chromePolicyService)
+	if diags.HasError() {
+		t.Fatalf("Error getting chrome policies service %+v", diags)
+	}
+
+	_, err = chromePoliciesService.Orgunits.BatchModify(fmt.Sprintf("customers/%s", client.Customer), &chromepolicy.GoogleChromePolicyV1BatchModifyOrgUnitPoliciesRequest{
+		Requests: []*chromepolicy.GoogleChromePolicyV1ModifyOrgUnitPolicyRequest{
+			{
+				PolicyTargetKey: &chromepolicy.GoogleChromePolicyV1PolicyTargetKey{
+					TargetResource: "orgunits/" + strings.TrimPrefix(orgUnitId, "id:"),
+				},
+				PolicyValue: &chromepolicy.GoogleChromePolicyV1PolicyValue{
+					PolicySchema: schemaName,
+					Value:        googleapi.RawMessage(value),
+				},
+				UpdateMask: updateMask,
+			},
+		},
+	}).Do()
+	if err != nil {
+		t.Fatalf("Error modifying chrome policy %s on org unit %s: %s", schemaName, orgUnitId, err)
+	}
+}
+
+// testAccResourceChromePolicyValueImportStateIdFunc builds the import id with
+// the org unit ID as configured, as the resource id has it without its "id:"
+// prefix.
+func testAccResourceChromePolicyValueImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		parts := strings.SplitN(rs.Primary.ID, "/", 2)
+		if len(parts) != 2 {
+			return "", fmt.Errorf("unexpected id: %s", rs.Primary.ID)
+		}
+
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["org_unit_id"], parts[1]), nil
+	}
+}
+
+func testAccResourceChromePolicyValue_basic(ouName string, conns int, pattern string) string {
+	return fmt.Sprintf(`
+resource "googleworkspace_org_unit" "test" {
+  name = "%s"
+  parent_org_unit_path = "/"
+}
+
+resource "googleworkspace_chrome_policy_value" "connections" {
+  org_unit_id = googleworkspace_org_unit.test.id
+  schema_name = "chrome.users.MaxConnectionsPerProxy"
+  schema_values = {
+    maxConnectionsPerProxy = jsonencode(%d)
+  }
+}
+
+resource "googleworkspace_chrome_policy_value" "signin" {
+  org_unit_id = googleworkspace_org_unit.test.id
+  schema_name = "chrome.users.RestrictSigninToPattern"
+  schema_values = {
+    restrictSigninToPattern = jsonencode("%s")
+  }
+}
+`, ouName, conns, pattern)
+}
+
+func testAccResourceChromePolicyValue_single(ouName string, conns int) string {
+	return fmt.Sprintf(`
+resource "googleworkspace_org_unit" "test" {
+  name = "%s"
+  parent_org_unit_path = "/"
+}
+
+resource "googleworkspace_chrome_policy_value" "connections" {
+  org_unit_id = googleworkspace_org_unit.test.id
+  schema_name = "chrome.users.MaxConnectionsPerProxy"
+  schema_values = {
+    maxConnectionsPerProxy = jsonencode(%d)
+  }
+}
+`, ouName, conns)
+}
+
+func testAccResourceChromePolicyValue_additionalTargetKeys(ouName, installType string) string {
+	return fmt.Sprintf(`
+resource "googleworkspace_org_unit" "test" {
+  name = "%s"
+  parent_org_unit_path = "/"
+}
+
+resource "googleworkspace_chrome_policy_value" "test" {
+  org_unit_id = googleworkspace_org_unit.test.id
+  schema_name = "chrome.users.apps.InstallType"
+
+  additional_target_keys = {
+    "app_id" = "chrome:aapbdbdomjkkjkaonfhkkikfgjllcleb"
+  }
+
+  schema_values = {
+    appInstallType = jsonencode("%s")
+  }
+}
+`, ouName, installType)
+}
+
+func testAccResourceChromePolicyValue_proxy(ouName string, withServer bool) string {
+	proxyServer := ""
+	if withServer {
+		proxyServer = `proxyServer = jsonencode("proxy.example.com:8080")`
+	}
+
+	return fmt.Sprintf(`
+resource "googleworkspace_org_unit" "test" {
+  name = "%s"
+  parent_org_unit_path = "/"
+}
+
+resource "googleworkspace_chrome_policy_value" "test" {
+  org_unit_id = googleworkspace_org_unit.test.id
+  schema_name = "chrome.users.ProxySettings"
+  schema_values = {
+    proxyMode = jsonencode("PROXY_MODE_ENUM_FIXED_SERVERS")
+    %s
+  }
+}
+`, ouName, proxyServer)
+}
@@ -93,7 +93,7 @@ func resourceDomainCreate(ctx context.Context, d *schema.ResourceData, meta inte
 		DomainName: d.Get("domain_name").(string),
 	}
 
-	domain, err := domainsService.Insert(client.Customer, &domainObj).Do()
+	domain, err := domainsService.Insert(client.Customer, &domainObj).Context(ctx).Do()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -171,7 +171,7 @@ func resourceDomainDelete(ctx context.Context, d *schema.ResourceData, meta inte
 		return diags
 	}
 
-	err := domainsService.Delete(client.Customer, domainName).Do()
+	err := domainsService.Delete(client.Customer, domainName).Context(ctx).Do()
 	if err != nil {
 		return handleNotFoundError(err, d, domainName)
 	}
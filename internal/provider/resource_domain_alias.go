@@ -87,7 +87,7 @@ func resourceDomainAliasCreate(ctx context.Context, d *schema.ResourceData, meta
 		DomainAliasName:  d.Get("domain_alias_name").(string),
 	}
 
-	domainAlias, err := domainAliasesService.Insert(client.Customer, &domainAliasObj).Do()
+	domainAlias, err := domainAliasesService.Insert(client.Customer, &domainAliasObj).Context(ctx).Do()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -162,7 +162,7 @@ func resourceDomainAliasDelete(ctx context.Context, d *schema.ResourceData, meta
 		return diags
 	}
 
-	err := domainAliasesService.Delete(client.Customer, domainAliasName).Do()
+	err := domainAliasesService.Delete(client.Customer, domainAliasName).Context(ctx).Do()
 	if err != nil {
 		return handleNotFoundError(err, d, domainAliasName)
 	}
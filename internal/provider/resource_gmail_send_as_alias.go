@@ -153,7 +153,7 @@ func resourceGmailSendAsAliasCreate(ctx context.Context, d *schema.ResourceData,
 		IsDefault:      d.Get("is_default").(bool),
 		TreatAsAlias:   d.Get("treat_as_alias").(bool),
 		SmtpMsa:        expandSmtpMsa(d.Get("smtp_msa").([]interface{})),
-	}).Do()
+	}).Context(ctx).Do()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -189,7 +189,7 @@ func resourceGmailSendAsAliasUpdate(ctx context.Context, d *schema.ResourceData,
 		IsDefault:      d.Get("is_default").(bool),
 		TreatAsAlias:   d.Get("treat_as_alias").(bool),
 		SmtpMsa:        expandSmtpMsa(d.Get("smtp_msa").([]interface{})),
-	}).Do()
+	}).Context(ctx).Do()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -256,7 +256,7 @@ func resourceGmailSendAsAliasDelete(ctx context.Context, d *schema.ResourceData,
 
 	log.Printf("[DEBUG] Deleting Gmail Send As Alias %q", d.Id())
 
-	err := sendAsAliasService.Delete("me", d.Get("send_as_email").(string)).Do()
+	err := sendAsAliasService.Delete("me", d.Get("send_as_email").(string)).Context(ctx).Do()
 	if err != nil {
 		return handleNotFoundError(err, d, d.Id())
 	}
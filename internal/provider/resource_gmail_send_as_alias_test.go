@@ -6,7 +6,6 @@ import (
 	"regexp"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -25,14 +24,14 @@ func TestAccResourceGmailSendAsAlias_basic(t *testing.T) {
 
 	data := map[string]interface{}{
 		"domainName": domainName,
-		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"password":   acctest.RandString(10),
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
 		"gmailUser":  gmailUser,
 	}
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccGmailSendAsAlias_basic(data),
@@ -61,14 +60,14 @@ func TestAccResourceGmailSendAsAlias_full(t *testing.T) {
 
 	data := map[string]interface{}{
 		"domainName": domainName,
-		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"password":   acctest.RandString(10),
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
 		"gmailUser":  gmailUser,
 	}
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccGmailSendAsAlias_basic(data),
@@ -105,14 +104,14 @@ func TestAccResourceGmailSendAsAlias_defaultCannotBeSetToFalseDirectly(t *testin
 
 	data := map[string]interface{}{
 		"domainName": domainName,
-		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"password":   acctest.RandString(10),
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
 		"gmailUser":  gmailUser,
 	}
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccGmailSendAsAlias_withDefault(data),
@@ -140,15 +139,15 @@ func TestAccResourceGmailSendAsAlias_switchingDefaultCausesDiff(t *testing.T) {
 
 	data := map[string]interface{}{
 		"domainName": domainName,
-		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"password":   acctest.RandString(10),
-		"userEmail2": fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
+		"userEmail2": fmt.Sprintf("tf-test-%s", randString(t, 10)),
 		"gmailUser":  gmailUser,
 	}
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccGmailSendAsAlias_withDefaultUser1(data),
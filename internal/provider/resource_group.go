@@ -77,9 +77,10 @@ func resourceGroup() *schema.Resource {
 				Computed:    true,
 			},
 			"aliases": {
-				Description: "asps.list of group's email addresses.",
-				Type:        schema.TypeList,
-				Optional:    true,
+				Description: "asps.list of group's email addresses. If any of the group's aliases are managed by " +
+					"`googleworkspace_group_alias`, add this to the `ignore_changes` of the group's `lifecycle`.",
+				Type:     schema.TypeList,
+				Optional: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -122,7 +123,7 @@ func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		Description: d.Get("description").(string),
 	}
 
-	group, err := groupsService.Insert(&groupObj).Do()
+	group, err := groupsService.Insert(&groupObj).Context(ctx).Do()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -146,7 +147,7 @@ func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, meta inter
 				Alias: d.Get(fmt.Sprintf("aliases.%d", i)).(string),
 			}
 
-			_, err := aliasesService.Insert(d.Id(), &aliasObj).Do()
+			_, err := aliasesService.Insert(d.Id(), &aliasObj).Context(ctx).Do()
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -274,7 +275,7 @@ func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 				continue
 			}
 
-			err := aliasesService.Delete(d.Id(), alias).Do()
+			err := aliasesService.Delete(d.Id(), alias).Context(ctx).Do()
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -290,7 +291,7 @@ func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 				Alias: alias,
 			}
 
-			_, err := aliasesService.Insert(d.Id(), &aliasObj).Do()
+			_, err := aliasesService.Insert(d.Id(), &aliasObj).Context(ctx).Do()
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -299,7 +300,7 @@ func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 	}
 
 	if &groupObj != new(directory.Group) {
-		group, err := groupsService.Update(d.Id(), &groupObj).Do()
+		group, err := groupsService.Update(d.Id(), &groupObj).Context(ctx).Do()
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -362,7 +363,7 @@ func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, meta inter
 		return diags
 	}
 
-	err := groupsService.Delete(d.Id()).Do()
+	err := groupsService.Delete(d.Id()).Context(ctx).Do()
 	if err != nil {
 		return handleNotFoundError(err, d, d.Get("email").(string))
 	}
@@ -0,0 +1,235 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func resourceGroupAlias() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Group Alias resource manages a single alias of a Google Workspace Group, so aliases can be " +
+			"managed separately from the group they belong to. The aliases of a group should either be managed by " +
+			"this resource, or by the `aliases` of `googleworkspace_group`, but not both. If the group is managed " +
+			"elsewhere, add `aliases` to the `ignore_changes` of its `lifecycle`.",
+
+		CreateContext: resourceGroupAliasCreate,
+		ReadContext:   resourceGroupAliasRead,
+		DeleteContext: resourceGroupAliasDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGroupAliasImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Description: "Identifies the group in the API request. The value can be the group's email address, " +
+					"group alias, or the unique group ID.",
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"alias": {
+				Description: "The alias email address.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"primary_email": {
+				Description: "The primary email address of the group.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceGroupAliasCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	groupId := d.Get("group_id").(string)
+	alias := d.Get("alias").(string)
+	log.Printf("[DEBUG] Creating Group Alias %q for Group %s", alias, groupId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	groupsService, diags := GetGroupsService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliasesService, diags := GetGroupAliasService(groupsService)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliasObj := directory.Alias{
+		Alias: alias,
+	}
+
+	// A group that was just created may not be found yet, as groups are
+	// eventually consistent
+	err := retryTimeDuration(ctx, d.Timeout(schema.TimeoutCreate), func() error {
+		_, retryErr := aliasesService.Insert(groupId, &aliasObj).Context(ctx).Do()
+		if isApiErrorWithCode(retryErr, 404) {
+			return fmt.Errorf("timed out while waiting for group %s to be found: %s", groupId, retryErr)
+		}
+
+		return retryErr
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", groupId, alias))
+
+	// INSERT responds with the alias, but it is eventually consistent, so wait
+	// until it's listed on the group before reading it
+	err = retryTimeDuration(ctx, d.Timeout(schema.TimeoutCreate), func() error {
+		found, retryErr := findGroupAlias(ctx, aliasesService, groupId, alias)
+		if retryErr != nil {
+			return fmt.Errorf("unexpected error during retries of group alias: %s", retryErr)
+		}
+
+		if found == nil {
+			return fmt.Errorf("timed out while waiting for group alias to be inserted")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Finished creating Group Alias %q for Group %s", alias, groupId)
+
+	return resourceGroupAliasRead(ctx, d, meta)
+}
+
+func resourceGroupAliasRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	groupId := d.Get("group_id").(string)
+	alias := d.Get("alias").(string)
+	log.Printf("[DEBUG] Getting Group Alias %q for Group %s", alias, groupId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	groupsService, diags := GetGroupsService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliasesService, diags := GetGroupAliasService(groupsService)
+	if diags.HasError() {
+		return diags
+	}
+
+	found, err := findGroupAlias(ctx, aliasesService, groupId, alias)
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	if found == nil {
+		log.Printf("[WARN] Removing %s because it's gone", d.Id())
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("primary_email", found.PrimaryEmail)
+
+	log.Printf("[DEBUG] Finished getting Group Alias %q for Group %s", alias, groupId)
+
+	return diags
+}
+
+func resourceGroupAliasDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	groupId := d.Get("group_id").(string)
+	alias := d.Get("alias").(string)
+	log.Printf("[DEBUG] Deleting Group Alias %q from Group %s", alias, groupId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	groupsService, diags := GetGroupsService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliasesService, diags := GetGroupAliasService(groupsService)
+	if diags.HasError() {
+		return diags
+	}
+
+	err := aliasesService.Delete(groupId, alias).Context(ctx).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	log.Printf("[DEBUG] Finished deleting Group Alias %q from Group %s", alias, groupId)
+
+	return diags
+}
+
+func resourceGroupAliasImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+
+	// id is of format "<group_id>/<alias>"
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("Group Alias Id (%s) is not of the correct format (<group_id>/<alias>)", d.Id())
+	}
+
+	d.Set("group_id", parts[0])
+	d.Set("alias", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// findGroupAlias returns the alias of the group, or nil if the group doesn't
+// have it.
+func findGroupAlias(ctx context.Context, aliasesService *directory.GroupsAliasesService, groupId, alias string) (*directory.Alias, error) {
+	aliases, err := aliasesService.List(groupId).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return findAlias(aliases, alias), nil
+}
@@ -0,0 +1,113 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceGroupAlias_basic(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testGroupVals := map[string]interface{}{
+		"domainName": domainName,
+		"groupEmail": fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"alias":      fmt.Sprintf("tf-test-%s", randString(t, 10)),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccResourceGroupAliasDestroyed(t, "googleworkspace_group_alias.my-alias"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceGroupAlias_basic(testGroupVals),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_group_alias.my-alias", "alias",
+						Nprintf("%{alias}@%{domainName}", testGroupVals)),
+					resource.TestCheckResourceAttrPair("googleworkspace_group_alias.my-alias", "primary_email",
+						"googleworkspace_group.my-group", "email"),
+				),
+			},
+			{
+				ResourceName:      "googleworkspace_group_alias.my-alias",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceGroupAliasDestroyed(t *testing.T, resource string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("%s key not found in state", resource)
+		}
+
+		client, err := testAccClient(t)
+		if err != nil {
+			return err
+		}
+
+		directoryService, diags := client.NewDirectoryService()
+		if diags.HasError() {
+			return fmt.Errorf("Error creating directory service %+v", diags)
+		}
+
+		groupsService, diags := GetGroupsService(directoryService)
+		if diags.HasError() {
+			return fmt.Errorf("Error getting groups service %+v", diags)
+		}
+
+		aliasesService, diags := GetGroupAliasService(groupsService)
+		if diags.HasError() {
+			return fmt.Errorf("Error getting group alias service %+v", diags)
+		}
+
+		parts := strings.Split(rs.Primary.ID, "/")
+
+		// id is of format "<group_id>/<alias>"
+		if len(parts) != 2 {
+			return fmt.Errorf("Group Alias Id (%s) is not of the correct format (<group_id>/<alias>)", rs.Primary.ID)
+		}
+
+		// the group is deleted along with the alias
+		alias, err := findGroupAlias(context.Background(), aliasesService, parts[0], parts[1])
+		if err == nil && alias != nil {
+			return fmt.Errorf("Group Alias still exists (%s)", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccResourceGroupAlias_basic(testGroupVals map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_group" "my-group" {
+  email = "%{groupEmail}@%{domainName}"
+
+  lifecycle {
+    ignore_changes = [aliases]
+  }
+}
+
+resource "googleworkspace_group_alias" "my-alias" {
+  group_id = googleworkspace_group.my-group.id
+  alias = "%{alias}@%{domainName}"
+}
+`, testGroupVals)
+}
@@ -137,7 +137,7 @@ func resourceGroupMemberCreate(ctx context.Context, d *schema.ResourceData, meta
 		DeliverySettings: d.Get("delivery_settings").(string),
 	}
 
-	member, err := membersService.Insert(groupId, &memberObj).Do()
+	member, err := membersService.Insert(groupId, &memberObj).Context(ctx).Do()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -228,7 +228,7 @@ func resourceGroupMemberUpdate(ctx context.Context, d *schema.ResourceData, meta
 	if &memberObj != new(directory.Member) {
 		groupId := d.Get("group_id").(string)
 		memberId := d.Get("member_id").(string)
-		member, err := membersService.Update(groupId, memberId, &memberObj).Do()
+		member, err := membersService.Update(groupId, memberId, &memberObj).Context(ctx).Do()
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -262,7 +262,7 @@ func resourceGroupMemberDelete(ctx context.Context, d *schema.ResourceData, meta
 		return diags
 	}
 
-	err := membersService.Delete(groupId, memberId).Do()
+	err := membersService.Delete(groupId, memberId).Context(ctx).Do()
 	if err != nil {
 		return handleNotFoundError(err, d, d.Id())
 	}
@@ -6,7 +6,6 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -22,16 +21,16 @@ func TestAccResourceGroupMember_basic(t *testing.T) {
 
 	testGroupVals := map[string]interface{}{
 		"domainName": domainName,
-		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"groupEmail": fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"password":   acctest.RandString(10),
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"groupEmail": fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
 	}
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		CheckDestroy: resource.ComposeTestCheckFunc(
-			testAccResourceGroupMemberExists("googleworkspace_group_member.my-group-member"),
+			testAccResourceGroupMemberExists(t, "googleworkspace_group_member.my-group-member"),
 		),
 		Steps: []resource.TestStep{
 			{
@@ -57,16 +56,16 @@ func TestAccResourceGroupMember_full(t *testing.T) {
 
 	testGroupVals := map[string]interface{}{
 		"domainName": domainName,
-		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"groupEmail": fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"password":   acctest.RandString(10),
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"groupEmail": fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
 	}
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		CheckDestroy: resource.ComposeTestCheckFunc(
-			testAccResourceGroupMemberExists("googleworkspace_group_member.my-group-member"),
+			testAccResourceGroupMemberExists(t, "googleworkspace_group_member.my-group-member"),
 		),
 		Steps: []resource.TestStep{
 			{
@@ -89,14 +88,14 @@ func TestAccResourceGroupMember_full(t *testing.T) {
 	})
 }
 
-func testAccResourceGroupMemberExists(resource string) resource.TestCheckFunc {
+func testAccResourceGroupMemberExists(t *testing.T, resource string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resource]
 		if !ok {
 			return fmt.Errorf("%s key not found in state", resource)
 		}
 
-		client, err := googleworkspaceTestClient()
+		client, err := testAccClient(t)
 		if err != nil {
 			return err
 		}
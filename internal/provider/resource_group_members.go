@@ -0,0 +1,440 @@
+package googleworkspace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+// The number of member changes made concurrently, so they can be combined into
+// batch requests when batching is enabled.
+const groupMembersConcurrency = 50
+
+func resourceGroupMembers() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "Group Members resource manages the full membership of a Google Workspace Group. The membership " +
+			"is authoritative: members of the group that aren't configured, including those added outside of " +
+			"Terraform, are removed from the group. The members of a group should either be managed by this " +
+			"resource, or by `googleworkspace_group_member`, but not both.",
+
+		CreateContext: resourceGroupMembersCreate,
+		ReadContext:   resourceGroupMembersRead,
+		UpdateContext: resourceGroupMembersUpdate,
+		DeleteContext: resourceGroupMembersDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGroupMembersImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Description: "Identifies the group in the API request. The value can be the group's email address, " +
+					"group alias, or the unique group ID.",
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"members": {
+				Description: "The members of the group.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Set:         resourceGroupMembersMemberHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"email": {
+							Description: "The member's email address. A member can be a user or another group. " +
+								"The email must be unique and cannot be an alias of another group. A `CUSTOMER` " +
+								"member has no email address, so it's identified by the customer ID instead.",
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"role": {
+							Description: "The member's role in a group. The API returns an error for cycles in " +
+								"group memberships. Acceptable values are `MANAGER`, `MEMBER` and `OWNER`. An `OWNER` " +
+								"can send messages to the group, add or remove members, change member roles, change " +
+								"group's settings, and delete the group. A `MANAGER` can do everything done by an " +
+								"`OWNER` except make a member an `OWNER` or delete the group. A `MEMBER` can subscribe " +
+								"to a group, view discussion archives, and view the group's membership list.",
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "MEMBER",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"MANAGER", "MEMBER", "OWNER"},
+								false)),
+						},
+						"type": {
+							Description: "The type of group member. Acceptable values are `CUSTOMER`, `GROUP` and `USER`. " +
+								"A `CUSTOMER` member represents all users in the customer's domains.",
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "USER",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"CUSTOMER", "GROUP", "USER"},
+								false)),
+						},
+						"delivery_settings": {
+							Description: "Defines mail delivery preferences of member. Acceptable values are:" +
+								"`ALL_MAIL`: All messages, delivered as soon as they arrive. " +
+								"`DAILY`: No more than one message a day. " +
+								"`DIGEST`: Up to 25 messages bundled into a single message. " +
+								"`DISABLED`: Remove subscription. " +
+								"`NONE`: No messages.",
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "ALL_MAIL",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"ALL_MAIL", "DAILY", "DIGEST",
+								"DISABLED", "NONE"}, false)),
+						},
+						"status": {
+							Description: "Status of member.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"id": {
+							Description: "The unique ID of the group member.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// resourceGroupMembersMemberHash hashes only the configurable fields of a
+// member, as the computed fields aren't known until the member is added.
+func resourceGroupMembersMemberHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+
+	buf.WriteString(fmt.Sprintf("%s-", strings.ToLower(m["email"].(string))))
+	buf.WriteString(fmt.Sprintf("%s-", m["role"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["type"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["delivery_settings"].(string)))
+
+	return schema.HashString(buf.String())
+}
+
+func resourceGroupMembersCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	groupId := d.Get("group_id").(string)
+	log.Printf("[DEBUG] Creating Group Members of Group %s", groupId)
+
+	d.SetId(fmt.Sprintf("groups/%s", groupId))
+
+	diags := resourceGroupMembersApply(ctx, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished creating Group Members of Group %s", groupId)
+
+	return resourceGroupMembersRead(ctx, d, meta)
+}
+
+func resourceGroupMembersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	groupId := d.Get("group_id").(string)
+	log.Printf("[DEBUG] Getting Group Members of Group %s", groupId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	membersService, diags := GetMembersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	members, err := listGroupMembers(ctx, membersService, groupId)
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	// keep the emails as they're configured, as the API may return them in a
+	// different case
+	configuredEmails := map[string]string{}
+	for _, m := range d.Get("members").(*schema.Set).List() {
+		email := m.(map[string]interface{})["email"].(string)
+		configuredEmails[strings.ToLower(email)] = email
+	}
+
+	var flattened []interface{}
+	for _, member := range members {
+		email := groupMemberEmail(member)
+		if configured, ok := configuredEmails[strings.ToLower(email)]; ok {
+			email = configured
+		}
+
+		flattened = append(flattened, map[string]interface{}{
+			"email":             email,
+			"role":              member.Role,
+			"type":              member.Type,
+			"delivery_settings": member.DeliverySettings,
+			"status":            member.Status,
+			"id":                member.Id,
+		})
+	}
+
+	if err := d.Set("members", flattened); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("groups/%s", groupId))
+
+	log.Printf("[DEBUG] Finished getting Group Members of Group %s", groupId)
+
+	return diags
+}
+
+func resourceGroupMembersUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	groupId := d.Get("group_id").(string)
+	log.Printf("[DEBUG] Updating Group Members of Group %s", groupId)
+
+	if d.HasChange("members") {
+		diags := resourceGroupMembersApply(ctx, d, meta)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	log.Printf("[DEBUG] Finished updating Group Members of Group %s", groupId)
+
+	return resourceGroupMembersRead(ctx, d, meta)
+}
+
+func resourceGroupMembersDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	groupId := d.Get("group_id").(string)
+	log.Printf("[DEBUG] Deleting Group Members of Group %s", groupId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	membersService, diags := GetMembersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	var changes []func() error
+	for _, m := range d.Get("members").(*schema.Set).List() {
+		email := m.(map[string]interface{})["email"].(string)
+
+		changes = append(changes, func() error {
+			log.Printf("[DEBUG] Removing Member %q from Group %s", email, groupId)
+			err := membersService.Delete(groupId, email).Context(ctx).Do()
+			if err != nil && !isApiErrorWithCode(err, 404) {
+				return fmt.Errorf("error removing member %q from group %s: %s", email, groupId, err)
+			}
+
+			return nil
+		})
+	}
+
+	diags = applyGroupMemberChanges(changes)
+	if diags.HasError() {
+		return diags
+	}
+
+	log.Printf("[DEBUG] Finished deleting Group Members of Group %s", groupId)
+
+	return diags
+}
+
+func resourceGroupMembersImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+
+	// id is of format "groups/<group_id>"
+	if len(parts) != 2 || parts[0] != "groups" || parts[1] == "" {
+		return nil, fmt.Errorf("Group Members Id (%s) is not of the correct format (groups/<group_id>)", d.Id())
+	}
+
+	d.Set("group_id", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceGroupMembersApply converges the membership of the group on the
+// configured members. Members are added, updated and removed based on the
+// membership listed by the API, so members added outside of Terraform are
+// removed as well.
+func resourceGroupMembersApply(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	groupId := d.Get("group_id").(string)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	membersService, diags := GetMembersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	existing, err := listGroupMembers(ctx, membersService, groupId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	current := map[string]*directory.Member{}
+	for _, member := range existing {
+		current[groupMemberKey(member)] = member
+	}
+
+	var changes []func() error
+	for _, m := range d.Get("members").(*schema.Set).List() {
+		config := m.(map[string]interface{})
+		member := &directory.Member{
+			Role:             config["role"].(string),
+			Type:             config["type"].(string),
+			DeliverySettings: config["delivery_settings"].(string),
+		}
+
+		// the customer is added by its ID, as it has no email address
+		if member.Type == "CUSTOMER" {
+			member.Id = config["email"].(string)
+		} else {
+			member.Email = config["email"].(string)
+		}
+
+		key := groupMemberKey(member)
+		found, ok := current[key]
+		delete(current, key)
+
+		if !ok {
+			changes = append(changes, func() error {
+				log.Printf("[DEBUG] Adding Member %q to Group %s", groupMemberEmail(member), groupId)
+				_, err := membersService.Insert(groupId, member).Context(ctx).Do()
+				if err != nil {
+					return fmt.Errorf("error adding member %q to group %s: %s", groupMemberEmail(member), groupId, err)
+				}
+
+				return nil
+			})
+			continue
+		}
+
+		if found.Role != member.Role || found.DeliverySettings != member.DeliverySettings {
+			changes = append(changes, func() error {
+				log.Printf("[DEBUG] Updating Member %q of Group %s", groupMemberEmail(member), groupId)
+				patch := &directory.Member{
+					Role:             member.Role,
+					DeliverySettings: member.DeliverySettings,
+				}
+				_, err := membersService.Patch(groupId, found.Id, patch).Context(ctx).Do()
+				if err != nil {
+					return fmt.Errorf("error updating member %q of group %s: %s", groupMemberEmail(member), groupId, err)
+				}
+
+				return nil
+			})
+		}
+	}
+
+	// anything left is not configured
+	for _, member := range current {
+		member := member
+		changes = append(changes, func() error {
+			log.Printf("[DEBUG] Removing unmanaged Member %q from Group %s", groupMemberEmail(member), groupId)
+			err := membersService.Delete(groupId, member.Id).Context(ctx).Do()
+			if err != nil && !isApiErrorWithCode(err, 404) {
+				return fmt.Errorf("error removing member %q from group %s: %s", groupMemberEmail(member), groupId, err)
+			}
+
+			return nil
+		})
+	}
+
+	return applyGroupMemberChanges(changes)
+}
+
+// applyGroupMemberChanges makes the changes concurrently, so large numbers of
+// them can be combined into batch requests, and returns every error.
+func applyGroupMemberChanges(changes []func() error) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	sem := make(chan struct{}, groupMembersConcurrency)
+	for _, change := range changes {
+		change := change
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := change(); err != nil {
+				mutex.Lock()
+				diags = append(diags, diag.FromErr(err)...)
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return diags
+}
+
+// listGroupMembers lists every direct member of the group.
+func listGroupMembers(ctx context.Context, membersService *directory.MembersService, groupId string) ([]*directory.Member, error) {
+	var members []*directory.Member
+	err := membersService.List(groupId).Context(ctx).Pages(ctx, func(resp *directory.Members) error {
+		members = append(members, resp.Members...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// groupMemberKey identifies the member within the group. Members that represent
+// the whole customer don't have an email address, so they're identified by the
+// customer ID.
+func groupMemberKey(member *directory.Member) string {
+	return strings.ToLower(groupMemberEmail(member))
+}
+
+// groupMemberEmail is the email address of the member as it's configured,
+// which is the customer ID for a member that represents the whole customer.
+func groupMemberEmail(member *directory.Member) string {
+	if member.Email == "" {
+		return member.Id
+	}
+
+	return member.Email
+}
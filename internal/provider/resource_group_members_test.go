@@ -0,0 +1,274 @@
+package googleworkspace
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func TestAccResourceGroupMembers_basic(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testGroupVals := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"groupEmail": fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccResourceGroupMembersDestroyed(t, "googleworkspace_group_members.my-group-members"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceGroupMembers_basic(testGroupVals),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_group_members.my-group-members", "members.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "googleworkspace_group_members.my-group-members",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccResourceGroupMembers_full(testGroupVals),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_group_members.my-group-members", "members.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("googleworkspace_group_members.my-group-members", "members.*",
+						map[string]string{
+							"role":              "OWNER",
+							"type":              "USER",
+							"delivery_settings": "DAILY",
+						}),
+					resource.TestCheckTypeSetElemNestedAttrs("googleworkspace_group_members.my-group-members", "members.*",
+						map[string]string{
+							"role": "MEMBER",
+							"type": "GROUP",
+						}),
+				),
+			},
+			{
+				ResourceName:      "googleworkspace_group_members.my-group-members",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceGroupMembers_removesUnmanaged(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testGroupVals := map[string]interface{}{
+		"domainName": domainName,
+		"groupEmail": fmt.Sprintf("tf-test-%s", randString(t, 10)),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccResourceGroupMembersDestroyed(t, "googleworkspace_group_members.my-group-members"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceGroupMembers_unmanaged(testGroupVals),
+			},
+			{
+				// a member added outside of Terraform shows up as drift
+				PreConfig: func() {
+					testAccAddUnmanagedGroupMember(t, Nprintf("%{groupEmail}@%{domainName}", testGroupVals),
+						Nprintf("%{groupEmail}-other@%{domainName}", testGroupVals))
+				},
+				Config:             testAccResourceGroupMembers_unmanaged(testGroupVals),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// and is removed on apply
+				Config: testAccResourceGroupMembers_unmanaged(testGroupVals),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_group_members.my-group-members", "members.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("googleworkspace_group_members.my-group-members", "members.*",
+						map[string]string{
+							"email": Nprintf("%{groupEmail}-sub@%{domainName}", testGroupVals),
+						}),
+				),
+			},
+		},
+	})
+}
+
+func testAccAddUnmanagedGroupMember(t *testing.T, groupEmail, memberEmail string) {
+	client, err := testAccClient(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		t.Fatalf("Error creating directory service %+v", diags)
+	}
+
+	membersService, diags := GetMembersService(directoryService)
+	if diags.HasError() {
+		t.Fatalf("Error getting group members service %+v", diags)
+	}
+
+	_, err = membersService.Insert(groupEmail, &directory.Member{
+		Email: memberEmail,
+		Type:  "GROUP",
+		Role:  "MEMBER",
+	}).Do()
+	if err != nil {
+		t.Fatalf("Error adding member %s to group %s: %s", memberEmail, groupEmail, err)
+	}
+}
+
+func testAccResourceGroupMembersDestroyed(t *testing.T, resource string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("%s key not found in state", resource)
+		}
+
+		client, err := testAccClient(t)
+		if err != nil {
+			return err
+		}
+
+		directoryService, diags := client.NewDirectoryService()
+		if diags.HasError() {
+			return fmt.Errorf("Error creating directory service %+v", diags)
+		}
+
+		membersService, diags := GetMembersService(directoryService)
+		if diags.HasError() {
+			return fmt.Errorf("Error getting group members service %+v", diags)
+		}
+
+		parts := strings.Split(rs.Primary.ID, "/")
+
+		// id is of format "groups/<group_id>"
+		if len(parts) != 2 {
+			return fmt.Errorf("Group Members Id (%s) is not of the correct format (groups/<group_id>)", rs.Primary.ID)
+		}
+
+		// the group is deleted along with its members
+		members, err := membersService.List(parts[1]).Do()
+		if err == nil && len(members.Members) > 0 {
+			return fmt.Errorf("Group Members still exist (%s)", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccResourceGroupMembers_basic(testGroupVals map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_group" "my-group" {
+  email = "%{groupEmail}@%{domainName}"
+}
+
+resource "googleworkspace_user" "my-new-user" {
+  primary_email = "%{userEmail}@%{domainName}"
+  password = "%{password}"
+
+  name {
+    family_name = "Scott"
+    given_name = "Michael"
+  }
+}
+
+resource "googleworkspace_group_members" "my-group-members" {
+  group_id = googleworkspace_group.my-group.id
+
+  members {
+    email = googleworkspace_user.my-new-user.primary_email
+  }
+}
+`, testGroupVals)
+}
+
+func testAccResourceGroupMembers_full(testGroupVals map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_group" "my-group" {
+  email = "%{groupEmail}@%{domainName}"
+}
+
+resource "googleworkspace_group" "my-sub-group" {
+  email = "%{groupEmail}-sub@%{domainName}"
+}
+
+resource "googleworkspace_user" "my-new-user" {
+  primary_email = "%{userEmail}@%{domainName}"
+  password = "%{password}"
+
+  name {
+    family_name = "Scott"
+    given_name = "Michael"
+  }
+}
+
+resource "googleworkspace_group_members" "my-group-members" {
+  group_id = googleworkspace_group.my-group.id
+
+  members {
+    email = googleworkspace_user.my-new-user.primary_email
+    role = "OWNER"
+    delivery_settings = "DAILY"
+  }
+
+  members {
+    email = googleworkspace_group.my-sub-group.email
+    type = "GROUP"
+  }
+}
+`, testGroupVals)
+}
+
+func testAccResourceGroupMembers_unmanaged(testGroupVals map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_group" "my-group" {
+  email = "%{groupEmail}@%{domainName}"
+}
+
+resource "googleworkspace_group" "my-sub-group" {
+  email = "%{groupEmail}-sub@%{domainName}"
+}
+
+resource "googleworkspace_group" "my-other-group" {
+  email = "%{groupEmail}-other@%{domainName}"
+}
+
+resource "googleworkspace_group_members" "my-group-members" {
+  group_id = googleworkspace_group.my-group.id
+
+  members {
+    email = googleworkspace_group.my-sub-group.email
+    type = "GROUP"
+  }
+}
+`, testGroupVals)
+}
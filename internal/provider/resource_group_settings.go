@@ -384,7 +384,7 @@ func resourceGroupSettingsCreate(ctx context.Context, d *schema.ResourceData, me
 			"CustomRolesEnabledForSettingsToBeMerged", "EnableCollaborativeInbox"},
 	}
 
-	groupSettings, err := groupsService.Update(email, &groupSettingsObj).Do()
+	groupSettings, err := groupsService.Update(email, &groupSettingsObj).Context(ctx).Do()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -670,7 +670,7 @@ func resourceGroupSettingsUpdate(ctx context.Context, d *schema.ResourceData, me
 		groupSettingsObj.ForceSendFields = forceSendFields
 	}
 
-	groupSettings, err := groupsService.Update(email, &groupSettingsObj).Do()
+	groupSettings, err := groupsService.Update(email, &groupSettingsObj).Context(ctx).Do()
 	if err != nil {
 		return diag.FromErr(err)
 	}
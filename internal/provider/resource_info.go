@@ -0,0 +1,61 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type resourceInfoContextKey string
+
+const resourceInfoKey resourceInfoContextKey = "resource"
+
+// resourceInfo identifies the resource an operation is running for, so requests
+// made by the operation can be attributed to it. Terraform doesn't give providers
+// the address of a resource, so it is identified by its type and id.
+type resourceInfo struct {
+	resourceType string
+	d            *schema.ResourceData
+}
+
+func (r *resourceInfo) String() string {
+	if r.d.Id() == "" {
+		return r.resourceType
+	}
+
+	return fmt.Sprintf("%s %q", r.resourceType, r.d.Id())
+}
+
+func resourceInfoFromContext(ctx context.Context) (*resourceInfo, bool) {
+	info, ok := ctx.Value(resourceInfoKey).(*resourceInfo)
+	return info, ok
+}
+
+// withResourceInfo wraps the operations of a resource to add the resource to
+// the context of each operation. The id is read when it's needed, as it is only
+// known partway through creating a resource.
+func withResourceInfo(resourceType string, r *schema.Resource) *schema.Resource {
+	wrap := func(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+
+		return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			ctx = context.WithValue(ctx, resourceInfoKey, &resourceInfo{
+				resourceType: resourceType,
+				d:            d,
+			})
+
+			return f(ctx, d, meta)
+		}
+	}
+
+	r.CreateContext = wrap(r.CreateContext)
+	r.ReadContext = wrap(r.ReadContext)
+	r.UpdateContext = wrap(r.UpdateContext)
+	r.DeleteContext = wrap(r.DeleteContext)
+
+	return r
+}
@@ -128,7 +128,7 @@ func resourceOrgUnitCreate(ctx context.Context, d *schema.ResourceData, meta int
 	var orgUnit *directory.OrgUnit
 	err := retryTimeDuration(ctx, time.Minute, func() error {
 		var retryErr error
-		orgUnit, retryErr = orgUnitsService.Insert(client.Customer, &orgUnitObj).Do()
+		orgUnit, retryErr = orgUnitsService.Insert(client.Customer, &orgUnitObj).Context(ctx).Do()
 		return retryErr
 	})
 	if err != nil {
@@ -215,7 +215,7 @@ func resourceOrgUnitUpdate(ctx context.Context, d *schema.ResourceData, meta int
 	orgUnitObj.ForceSendFields = forceSendFields
 
 	if &orgUnitObj != new(directory.OrgUnit) {
-		orgUnit, err := orgUnitsService.Update(client.Customer, d.Id(), &orgUnitObj).Do()
+		orgUnit, err := orgUnitsService.Update(client.Customer, d.Id(), &orgUnitObj).Context(ctx).Do()
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -247,7 +247,7 @@ func resourceOrgUnitDelete(ctx context.Context, d *schema.ResourceData, meta int
 		return diags
 	}
 
-	err := orgUnitsService.Delete(client.Customer, d.Id()).Do()
+	err := orgUnitsService.Delete(client.Customer, d.Id()).Context(ctx).Do()
 	if err != nil {
 		return handleNotFoundError(err, d, d.Id())
 	}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -12,13 +11,13 @@ import (
 func TestAccResourceOrgUnit_basic(t *testing.T) {
 	t.Parallel()
 
-	ouName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	ouName := fmt.Sprintf("tf-test-%s", randString(t, 10))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		CheckDestroy: resource.ComposeTestCheckFunc(
-			testAccResourceOrgUnitMemberExists("googleworkspace_org_unit.my-org-unit"),
+			testAccResourceOrgUnitMemberExists(t, "googleworkspace_org_unit.my-org-unit"),
 		),
 		Steps: []resource.TestStep{
 			{
@@ -36,13 +35,13 @@ func TestAccResourceOrgUnit_basic(t *testing.T) {
 func TestAccResourceOrgUnit_full(t *testing.T) {
 	t.Parallel()
 
-	ouName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	ouName := fmt.Sprintf("tf-test-%s", randString(t, 10))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		CheckDestroy: resource.ComposeTestCheckFunc(
-			testAccResourceOrgUnitMemberExists("googleworkspace_org_unit.my-org-unit"),
+			testAccResourceOrgUnitMemberExists(t, "googleworkspace_org_unit.my-org-unit"),
 		),
 		Steps: []resource.TestStep{
 			{
@@ -65,14 +64,14 @@ func TestAccResourceOrgUnit_full(t *testing.T) {
 	})
 }
 
-func testAccResourceOrgUnitMemberExists(resource string) resource.TestCheckFunc {
+func testAccResourceOrgUnitMemberExists(t *testing.T, resource string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resource]
 		if !ok {
 			return fmt.Errorf("%s key not found in state", resource)
 		}
 
-		client, err := googleworkspaceTestClient()
+		client, err := testAccClient(t)
 		if err != nil {
 			return err
 		}
@@ -94,7 +94,7 @@ func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, meta interf
 
 	log.Printf("[DEBUG] Creating Role %q", d.Get("name").(string))
 
-	role, err := rolesService.Insert(client.Customer, getRole(d)).Do()
+	role, err := rolesService.Insert(client.Customer, getRole(d)).Context(ctx).Do()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -120,7 +120,7 @@ func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 
 	log.Printf("[DEBUG] Updating Role %q", d.Id())
 
-	_, err := rolesService.Update(client.Customer, d.Id(), getRole(d)).Do()
+	_, err := rolesService.Update(client.Customer, d.Id(), getRole(d)).Context(ctx).Do()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -179,7 +179,7 @@ func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, meta interf
 		return diags
 	}
 
-	err := roleService.Delete(client.Customer, d.Id()).Do()
+	err := roleService.Delete(client.Customer, d.Id()).Context(ctx).Do()
 	if err != nil {
 		return handleNotFoundError(err, d, d.Id())
 	}
@@ -221,14 +221,7 @@ func setRole(d *schema.ResourceData, role *directory.Role) diag.Diagnostics {
 	d.Set("is_super_admin_role", role.IsSuperAdminRole)
 	d.Set("etag", role.Etag)
 
-	privileges := make([]interface{}, len(role.RolePrivileges))
-	for i, priv := range role.RolePrivileges {
-		privileges[i] = map[string]interface{}{
-			"service_id":     priv.ServiceId,
-			"privilege_name": priv.PrivilegeName,
-		}
-	}
-	if err := d.Set("privileges", privileges); err != nil {
+	if err := d.Set("privileges", flattenRolePrivileges(role.RolePrivileges)); err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity:      diag.Error,
 			Summary:       "Error setting attribute",
@@ -239,3 +232,15 @@ func setRole(d *schema.ResourceData, role *directory.Role) diag.Diagnostics {
 
 	return diags
 }
+
+func flattenRolePrivileges(rolePrivileges []*directory.RoleRolePrivileges) []interface{} {
+	privileges := make([]interface{}, len(rolePrivileges))
+	for i, priv := range rolePrivileges {
+		privileges[i] = map[string]interface{}{
+			"service_id":     priv.ServiceId,
+			"privilege_name": priv.PrivilegeName,
+		}
+	}
+
+	return privileges
+}
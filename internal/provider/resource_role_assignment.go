@@ -115,7 +115,7 @@ func resourceRolesAssignmentCreate(ctx context.Context, d *schema.ResourceData,
 		OrgUnitId:  orgUnitId,
 	}
 
-	ra, err = roleAssignmentsService.Insert(client.Customer, ra).Do()
+	ra, err = roleAssignmentsService.Insert(client.Customer, ra).Context(ctx).Do()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -181,7 +181,7 @@ func resourceRoleAssignmentDelete(ctx context.Context, d *schema.ResourceData, m
 		return diags
 	}
 
-	err := roleAssignmentsService.Delete(client.Customer, d.Id()).Do()
+	err := roleAssignmentsService.Delete(client.Customer, d.Id()).Context(ctx).Do()
 	if err != nil {
 		return handleNotFoundError(err, d, d.Id())
 	}
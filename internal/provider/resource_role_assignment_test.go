@@ -6,7 +6,6 @@ import (
 	"regexp"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -21,12 +20,12 @@ func TestAccResourceRoleAssignment_basic(t *testing.T) {
 
 	data := map[string]interface{}{
 		"domainName": domainName,
-		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"password":   acctest.RandString(10),
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
 	}
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccRoleAssignment_basic(data),
@@ -51,13 +50,13 @@ func TestAccResourceRoleAssignment_orgUnit_invalid(t *testing.T) {
 
 	data := map[string]interface{}{
 		"domainName": domainName,
-		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"roleName":   fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"password":   acctest.RandString(10),
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"roleName":   fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
 	}
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config:      testAccRoleAssignment_orgUnit_invalid(data),
@@ -78,14 +77,14 @@ func TestAccResourceRoleAssignment_orgUnit(t *testing.T) {
 
 	data := map[string]interface{}{
 		"domainName": domainName,
-		"userEmail":  fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"roleName":   fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"ouName":     fmt.Sprintf("tf-test-%s", acctest.RandString(10)),
-		"password":   acctest.RandString(10),
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"roleName":   fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"ouName":     fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
 	}
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccRoleAssignment_orgUnit(data),
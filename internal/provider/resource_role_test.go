@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -13,10 +12,10 @@ func TestAccResourceRole_basic(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRole_basic(fmt.Sprintf("tf-test-%s", acctest.RandString(10)), "test"),
+				Config: testAccRole_basic(fmt.Sprintf("tf-test-%s", randString(t, 10)), "test"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("googleworkspace_role.test", "privileges.#", "9"),
 				),
@@ -35,10 +34,10 @@ func TestAccResourceRole_full(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccRole_basic(fmt.Sprintf("tf-test-%s", acctest.RandString(10)), "test"),
+				Config: testAccRole_basic(fmt.Sprintf("tf-test-%s", randString(t, 10)), "test"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("googleworkspace_role.test", "privileges.#", "9"),
 				),
@@ -49,7 +48,7 @@ func TestAccResourceRole_full(t *testing.T) {
 				ImportStateVerify: true,
 			},
 			{
-				Config: testAccRole_update(fmt.Sprintf("tf-test-%s", acctest.RandString(10)), "update"),
+				Config: testAccRole_update(fmt.Sprintf("tf-test-%s", randString(t, 10)), "update"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("googleworkspace_role.test", "privileges.#", "13"),
 				),
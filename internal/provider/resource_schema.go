@@ -182,7 +182,7 @@ func resourceSchemaCreate(ctx context.Context, d *schema.ResourceData, meta inte
 	}
 
 	err := retryTimeDuration(ctx, d.Timeout(schema.TimeoutCreate), func() error {
-		definedSchema, retryErr := schemasService.Insert(client.Customer, &schemaObj).Do()
+		definedSchema, retryErr := schemasService.Insert(client.Customer, &schemaObj).Context(ctx).Do()
 		if retryErr != nil {
 			return retryErr
 		}
@@ -282,7 +282,7 @@ func resourceSchemaUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 		schemaObj.SchemaId = d.Id()
 
 		err := retryTimeDuration(ctx, d.Timeout(schema.TimeoutUpdate), func() error {
-			definedSchema, retryErr := schemasService.Update(client.Customer, d.Id(), &schemaObj).Do()
+			definedSchema, retryErr := schemasService.Update(client.Customer, d.Id(), &schemaObj).Context(ctx).Do()
 			if retryErr != nil {
 				return retryErr
 			}
@@ -320,7 +320,7 @@ func resourceSchemaDelete(ctx context.Context, d *schema.ResourceData, meta inte
 	}
 
 	err := retryTimeDuration(ctx, d.Timeout(schema.TimeoutDelete), func() error {
-		retryErr := schemasService.Delete(client.Customer, d.Id()).Do()
+		retryErr := schemasService.Delete(client.Customer, d.Id()).Context(ctx).Do()
 		if retryErr != nil {
 			return retryErr
 		}
@@ -4,18 +4,17 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccResourceSchema_basic(t *testing.T) {
 	t.Parallel()
 
-	schemaName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	schemaName := fmt.Sprintf("tf-test-%s", randString(t, 10))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceSchema_basic(schemaName),
@@ -32,11 +31,11 @@ func TestAccResourceSchema_basic(t *testing.T) {
 func TestAccResourceSchema_full(t *testing.T) {
 	t.Parallel()
 
-	schemaName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	schemaName := fmt.Sprintf("tf-test-%s", randString(t, 10))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		ProviderFactories: testAccProviderFactories(t),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccResourceSchema_full(schemaName),
@@ -357,9 +357,10 @@ func resourceUser() *schema.Resource {
 				},
 			},
 			"aliases": {
-				Description: "asps.list of the user's alias email addresses.",
-				Type:        schema.TypeList,
-				Optional:    true,
+				Description: "asps.list of the user's alias email addresses. If any of the user's aliases are managed " +
+					"by `googleworkspace_user_alias`, add this to the `ignore_changes` of the user's `lifecycle`.",
+				Type:     schema.TypeList,
+				Optional: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -1077,7 +1078,7 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta interf
 		userObj.CustomSchemas = customSchemas
 	}
 
-	user, err := usersService.Insert(&userObj).Do()
+	user, err := usersService.Insert(&userObj).Context(ctx).Do()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -1101,7 +1102,7 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta interf
 				Alias: d.Get(fmt.Sprintf("aliases.%d", i)).(string),
 			}
 
-			_, err := aliasesService.Insert(d.Id(), &aliasObj).Do()
+			_, err := aliasesService.Insert(d.Id(), &aliasObj).Context(ctx).Do()
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -1114,7 +1115,7 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta interf
 			Status: d.Get("is_admin").(bool),
 		}
 
-		err = usersService.MakeAdmin(d.Id(), &makeAdminObj).Do()
+		err = usersService.MakeAdmin(d.Id(), &makeAdminObj).Context(ctx).Do()
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -1427,7 +1428,7 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 				continue
 			}
 
-			err := aliasesService.Delete(d.Id(), alias).Do()
+			err := aliasesService.Delete(d.Id(), alias).Context(ctx).Do()
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -1444,7 +1445,7 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 				Alias: alias,
 			}
 
-			_, err := aliasesService.Insert(d.Id(), &aliasObj).Do()
+			_, err := aliasesService.Insert(d.Id(), &aliasObj).Context(ctx).Do()
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -1458,7 +1459,7 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 			ForceSendFields: []string{"Status"},
 		}
 
-		err := usersService.MakeAdmin(d.Id(), &makeAdminObj).Do()
+		err := usersService.MakeAdmin(d.Id(), &makeAdminObj).Context(ctx).Do()
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -1466,7 +1467,7 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 	}
 
 	if &userObj != new(directory.User) {
-		_, err := usersService.Update(d.Id(), &userObj).Do()
+		_, err := usersService.Update(d.Id(), &userObj).Context(ctx).Do()
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -1527,7 +1528,7 @@ func resourceUserDelete(ctx context.Context, d *schema.ResourceData, meta interf
 		return diags
 	}
 
-	err := usersService.Delete(d.Id()).Do()
+	err := usersService.Delete(d.Id()).Context(ctx).Do()
 	if err != nil {
 		return handleNotFoundError(err, d, primaryEmail)
 	}
@@ -1766,6 +1767,13 @@ func expandCustomSchemaValues(customSchemas []interface{}) (map[string]googleapi
 }
 
 func flattenCustomSchemas(schemaAttrObj interface{}, client *apiClient) ([]map[string]interface{}, diag.Diagnostics) {
+	return flattenCustomSchemasWithDefinitions(schemaAttrObj, client, map[string]*directory.Schema{})
+}
+
+// flattenCustomSchemasWithDefinitions flattens the custom schemas, using the
+// schema definitions that were already retrieved. The definitions that are
+// retrieved are added to schemaDefs, so they can be shared across users.
+func flattenCustomSchemasWithDefinitions(schemaAttrObj interface{}, client *apiClient, schemaDefs map[string]*directory.Schema) ([]map[string]interface{}, diag.Diagnostics) {
 	var customSchemas []map[string]interface{}
 
 	directoryService, diags := client.NewDirectoryService()
@@ -1779,9 +1787,15 @@ func flattenCustomSchemas(schemaAttrObj interface{}, client *apiClient) ([]map[s
 	}
 
 	for schemaName, sv := range schemaAttrObj.(map[string]googleapi.RawMessage) {
-		schemaDef, err := schemaService.Get(client.Customer, schemaName).Do()
-		if err != nil {
-			return nil, diag.FromErr(err)
+		schemaDef, ok := schemaDefs[schemaName]
+		if !ok {
+			var err error
+			schemaDef, err = schemaService.Get(client.Customer, schemaName).Do()
+			if err != nil {
+				return nil, diag.FromErr(err)
+			}
+
+			schemaDefs[schemaName] = schemaDef
 		}
 
 		schemaFieldMap := map[string]*directory.SchemaFieldSpec{}
@@ -1791,7 +1805,7 @@ func flattenCustomSchemas(schemaAttrObj interface{}, client *apiClient) ([]map[s
 
 		var schemaValuesObj map[string]interface{}
 
-		err = json.Unmarshal(sv, &schemaValuesObj)
+		err := json.Unmarshal(sv, &schemaValuesObj)
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
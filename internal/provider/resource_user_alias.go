@@ -0,0 +1,235 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+func resourceUserAlias() *schema.Resource {
+	return &schema.Resource{
+		// This description is used by the documentation generator and the language server.
+		Description: "User Alias resource manages a single alias of a Google Workspace User, so aliases can be " +
+			"managed separately from the user they belong to. The aliases of a user should either be managed by " +
+			"this resource, or by the `aliases` of `googleworkspace_user`, but not both. If the user is managed " +
+			"elsewhere, add `aliases` to the `ignore_changes` of its `lifecycle`.",
+
+		CreateContext: resourceUserAliasCreate,
+		ReadContext:   resourceUserAliasRead,
+		DeleteContext: resourceUserAliasDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceUserAliasImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Description: "Identifies the user in the API request. The value can be the user's primary email address, " +
+					"alias email address, or unique user ID.",
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"alias": {
+				Description: "The alias email address.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"primary_email": {
+				Description: "The primary email address of the user.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			// Adding a computed id simply to override the `optional` id that gets added in the SDK
+			// that will then display improperly in the docs
+			"id": {
+				Description: "The ID of this resource.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceUserAliasCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userId := d.Get("user_id").(string)
+	alias := d.Get("alias").(string)
+	log.Printf("[DEBUG] Creating User Alias %q for User %s", alias, userId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliasesService, diags := GetUserAliasService(usersService)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliasObj := directory.Alias{
+		Alias: alias,
+	}
+
+	// A user that was just created may not be found yet, as users are
+	// eventually consistent
+	err := retryTimeDuration(ctx, d.Timeout(schema.TimeoutCreate), func() error {
+		_, retryErr := aliasesService.Insert(userId, &aliasObj).Context(ctx).Do()
+		if isApiErrorWithCode(retryErr, 404) {
+			return fmt.Errorf("timed out while waiting for user %s to be found: %s", userId, retryErr)
+		}
+
+		return retryErr
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", userId, alias))
+
+	// INSERT responds with the alias, but it is eventually consistent, so wait
+	// until it's listed on the user before reading it
+	err = retryTimeDuration(ctx, d.Timeout(schema.TimeoutCreate), func() error {
+		found, retryErr := findUserAlias(ctx, aliasesService, userId, alias)
+		if retryErr != nil {
+			return fmt.Errorf("unexpected error during retries of user alias: %s", retryErr)
+		}
+
+		if found == nil {
+			return fmt.Errorf("timed out while waiting for user alias to be inserted")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Finished creating User Alias %q for User %s", alias, userId)
+
+	return resourceUserAliasRead(ctx, d, meta)
+}
+
+func resourceUserAliasRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userId := d.Get("user_id").(string)
+	alias := d.Get("alias").(string)
+	log.Printf("[DEBUG] Getting User Alias %q for User %s", alias, userId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliasesService, diags := GetUserAliasService(usersService)
+	if diags.HasError() {
+		return diags
+	}
+
+	found, err := findUserAlias(ctx, aliasesService, userId, alias)
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	if found == nil {
+		log.Printf("[WARN] Removing %s because it's gone", d.Id())
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("primary_email", found.PrimaryEmail)
+
+	log.Printf("[DEBUG] Finished getting User Alias %q for User %s", alias, userId)
+
+	return diags
+}
+
+func resourceUserAliasDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// use the meta value to retrieve your client from the provider configure method
+	client := meta.(*apiClient)
+
+	userId := d.Get("user_id").(string)
+	alias := d.Get("alias").(string)
+	log.Printf("[DEBUG] Deleting User Alias %q from User %s", alias, userId)
+
+	directoryService, diags := client.NewDirectoryService()
+	if diags.HasError() {
+		return diags
+	}
+
+	usersService, diags := GetUsersService(directoryService)
+	if diags.HasError() {
+		return diags
+	}
+
+	aliasesService, diags := GetUserAliasService(usersService)
+	if diags.HasError() {
+		return diags
+	}
+
+	err := aliasesService.Delete(userId, alias).Context(ctx).Do()
+	if err != nil {
+		return handleNotFoundError(err, d, d.Id())
+	}
+
+	log.Printf("[DEBUG] Finished deleting User Alias %q from User %s", alias, userId)
+
+	return diags
+}
+
+func resourceUserAliasImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+
+	// id is of format "<user_id>/<alias>"
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("User Alias Id (%s) is not of the correct format (<user_id>/<alias>)", d.Id())
+	}
+
+	d.Set("user_id", parts[0])
+	d.Set("alias", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// findUserAlias returns the alias of the user, or nil if the user doesn't
+// have it.
+func findUserAlias(ctx context.Context, aliasesService *directory.UsersAliasesService, userId, alias string) (*directory.Alias, error) {
+	aliases, err := aliasesService.List(userId).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	return findAlias(aliases, alias), nil
+}
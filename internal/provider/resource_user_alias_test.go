@@ -0,0 +1,145 @@
+package googleworkspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceUserAlias_basic(t *testing.T) {
+	t.Parallel()
+
+	domainName := os.Getenv("GOOGLEWORKSPACE_DOMAIN")
+
+	if domainName == "" {
+		t.Skip("GOOGLEWORKSPACE_DOMAIN needs to be set to run this test")
+	}
+
+	testUserVals := map[string]interface{}{
+		"domainName": domainName,
+		"userEmail":  fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"alias":      fmt.Sprintf("tf-test-%s", randString(t, 10)),
+		"password":   randString(t, 10),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories(t),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccResourceUserAliasDestroyed(t, "googleworkspace_user_alias.my-alias"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceUserAlias_basic(testUserVals),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("googleworkspace_user_alias.my-alias", "alias",
+						Nprintf("%{alias}@%{domainName}", testUserVals)),
+					resource.TestCheckResourceAttrPair("googleworkspace_user_alias.my-alias", "primary_email",
+						"googleworkspace_user.my-new-user", "primary_email"),
+				),
+			},
+			{
+				ResourceName:      "googleworkspace_user_alias.my-alias",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceUserAliasImport(t *testing.T) {
+	r := resourceUserAlias()
+
+	d := r.TestResourceData()
+	d.SetId("123456789012345678901/support@example.com")
+
+	if _, err := resourceUserAliasImport(context.Background(), d, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if d.Get("user_id") != "123456789012345678901" {
+		t.Errorf("expected user_id to be imported, got %v", d.Get("user_id"))
+	}
+	if d.Get("alias") != "support@example.com" {
+		t.Errorf("expected alias to be imported, got %v", d.Get("alias"))
+	}
+
+	for _, id := range []string{"users/123456789012345678901/aliases/support@example.com", "support@example.com", "/support@example.com"} {
+		d.SetId(id)
+		if _, err := resourceUserAliasImport(context.Background(), d, nil); err == nil {
+			t.Errorf("expected an error importing %q", id)
+		}
+	}
+}
+
+func testAccResourceUserAliasDestroyed(t *testing.T, resource string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resource]
+		if !ok {
+			return fmt.Errorf("%s key not found in state", resource)
+		}
+
+		client, err := testAccClient(t)
+		if err != nil {
+			return err
+		}
+
+		directoryService, diags := client.NewDirectoryService()
+		if diags.HasError() {
+			return fmt.Errorf("Error creating directory service %+v", diags)
+		}
+
+		usersService, diags := GetUsersService(directoryService)
+		if diags.HasError() {
+			return fmt.Errorf("Error getting users service %+v", diags)
+		}
+
+		aliasesService, diags := GetUserAliasService(usersService)
+		if diags.HasError() {
+			return fmt.Errorf("Error getting user alias service %+v", diags)
+		}
+
+		parts := strings.Split(rs.Primary.ID, "/")
+
+		// id is of format "<user_id>/<alias>"
+		if len(parts) != 2 {
+			return fmt.Errorf("User Alias Id (%s) is not of the correct format (<user_id>/<alias>)", rs.Primary.ID)
+		}
+
+		// the user is deleted along with the alias
+		alias, err := findUserAlias(context.Background(), aliasesService, parts[0], parts[1])
+		if err == nil && alias != nil {
+			return fmt.Errorf("User Alias still exists (%s)", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccResourceUserAlias_basic(testUserVals map[string]interface{}) string {
+	return Nprintf(`
+resource "googleworkspace_user" "my-new-user" {
+  primary_email = "%{userEmail}@%{domainName}"
+  password = "%{password}"
+
+  name {
+    family_name = "Scott"
+    given_name = "Michael"
+  }
+
+  lifecycle {
+    ignore_changes = [aliases]
+  }
+}
+
+resource "googleworkspace_user_alias" "my-alias" {
+  user_id = googleworkspace_user.my-new-user.id
+  alias = "%{alias}@%{domainName}"
+}
+`, testUserVals)
+}
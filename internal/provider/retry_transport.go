@@ -0,0 +1,134 @@
+package googleworkspace
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+// retryTransport retries requests that fail with an error the retry policy
+// considers retryable, so every call to the Google Workspace APIs is retried
+// consistently regardless of the resource that makes it.
+type retryTransport struct {
+	base   http.RoundTripper
+	policy *retryPolicy
+}
+
+func newRetryTransport(base http.RoundTripper, policy *retryPolicy) *retryTransport {
+	return &retryTransport{
+		base:   base,
+		policy: policy,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 {
+			var err error
+			attemptReq, err = rewindRequest(req)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if err != nil {
+			return resp, err
+		}
+
+		// Requests whose body can't be replayed are only attempted once.
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+
+		retryable, resp := t.checkResponse(resp)
+		if !retryable {
+			return resp, nil
+		}
+
+		wait := t.policy.backoff(attempt)
+		if retryAfter := parseRetryAfter(resp.Header.Get("Retry-After")); retryAfter > wait {
+			wait = retryAfter
+		}
+
+		if time.Since(start)+wait > t.policy.MaxElapsedTime {
+			log.Printf("[DEBUG] Giving up retrying %s %s after %d attempts", req.Method, req.URL, attempt+1)
+			return resp, nil
+		}
+
+		// The response is discarded in favor of the next attempt.
+		resp.Body.Close()
+
+		log.Printf("[DEBUG] Retrying %s %s in %s (attempt %d)", req.Method, req.URL, wait, attempt+1)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// checkResponse reports whether the response is a retryable error. The body is
+// read to determine the error reasons, so the returned response has a fresh body.
+func (t *retryTransport) checkResponse(resp *http.Response) (bool, *http.Response) {
+	if resp.StatusCode < 400 {
+		return false, resp
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false, resp
+	}
+
+	apiErr := googleapi.CheckResponse(&http.Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+	})
+	if apiErr == nil {
+		return false, resp
+	}
+
+	return t.policy.isRetryable(apiErr), resp
+}
+
+// rewindRequest returns a copy of the request with a fresh body for another attempt.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	newReq := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		newReq.Body = body
+	}
+
+	return newReq, nil
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+
+	seconds, err := strconv.Atoi(v)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
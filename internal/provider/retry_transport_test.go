@@ -0,0 +1,165 @@
+package googleworkspace
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testBackendErrorBody = `{"error": {"code": 500, "message": "Backend Error", "errors": [{"reason": "backendError"}]}}`
+
+func testRetryPolicy() *retryPolicy {
+	return &retryPolicy{
+		MaxElapsedTime: time.Second,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+	}
+}
+
+// testFlakyServer fails the first `failures` requests with the given status and body.
+func testFlakyServer(t *testing.T, failures int, status int, body string, requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqBody, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("error reading request body: %s", err)
+		}
+		*requests = append(*requests, string(reqBody))
+
+		w.Header().Set("Content-Type", "application/json")
+		if len(*requests) <= failures {
+			w.WriteHeader(status)
+			w.Write([]byte(body))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+}
+
+func TestRetryTransport_temporarilyUnavailable(t *testing.T) {
+	var requests []string
+	server := testFlakyServer(t, 2, http.StatusServiceUnavailable, `{"error": {"code": 503, "message": "unavailable"}}`, &requests)
+	defer server.Close()
+
+	client := &http.Client{Transport: newRetryTransport(http.DefaultTransport, testRetryPolicy())}
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"name": "foo"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(requests))
+	}
+	for _, body := range requests {
+		if body != `{"name": "foo"}` {
+			t.Fatalf("expected request body to be replayed, got %q", body)
+		}
+	}
+}
+
+func TestRetryTransport_notRetryable(t *testing.T) {
+	var requests []string
+	server := testFlakyServer(t, 1, http.StatusInternalServerError, testBackendErrorBody, &requests)
+	defer server.Close()
+
+	client := &http.Client{Transport: newRetryTransport(http.DefaultTransport, testRetryPolicy())}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected status 500, got %d", resp.StatusCode)
+	}
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+
+	// the response body should still be readable by the caller
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(body) != testBackendErrorBody {
+		t.Fatalf("expected body %q, got %q", testBackendErrorBody, body)
+	}
+}
+
+func TestRetryTransport_configuredErrorReason(t *testing.T) {
+	var requests []string
+	server := testFlakyServer(t, 1, http.StatusInternalServerError, testBackendErrorBody, &requests)
+	defer server.Close()
+
+	policy := testRetryPolicy()
+	policy.RetryableErrorReasons = []string{"backendError"}
+	client := &http.Client{Transport: newRetryTransport(http.DefaultTransport, policy)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+}
+
+func TestRetryTransport_configuredStatusCode(t *testing.T) {
+	var requests []string
+	server := testFlakyServer(t, 1, http.StatusInternalServerError, testBackendErrorBody, &requests)
+	defer server.Close()
+
+	policy := testRetryPolicy()
+	policy.RetryableStatusCodes = []int{500}
+	client := &http.Client{Transport: newRetryTransport(http.DefaultTransport, policy)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(requests))
+	}
+}
+
+func TestRetryTransport_maxElapsedTime(t *testing.T) {
+	var requests []string
+	server := testFlakyServer(t, 1000, http.StatusTooManyRequests, `{"error": {"code": 429, "message": "slow down"}}`, &requests)
+	defer server.Close()
+
+	policy := testRetryPolicy()
+	policy.MaxElapsedTime = 50 * time.Millisecond
+	client := &http.Client{Transport: newRetryTransport(http.DefaultTransport, policy)}
+
+	start := time.Now()
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", resp.StatusCode)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatalf("expected retries to stop after the max elapsed time, took %s", time.Since(start))
+	}
+	if len(requests) < 2 {
+		t.Fatalf("expected the request to be retried, got %d requests", len(requests))
+	}
+}
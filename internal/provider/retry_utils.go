@@ -3,6 +3,7 @@ package googleworkspace
 import (
 	"context"
 	"log"
+	"math/rand"
 	"regexp"
 	"strings"
 	"time"
@@ -12,6 +13,9 @@ import (
 	"google.golang.org/api/googleapi"
 )
 
+// retryTimeDuration retries retryFunc while the API isn't consistent yet, such
+// as when reading a resource right after it's written. Unavailable and rate
+// limited requests are already retried by the transport, see retryTransport.
 func retryTimeDuration(ctx context.Context, duration time.Duration, retryFunc func() error) error {
 	return resource.RetryContext(ctx, duration, func() *resource.RetryError {
 		err := retryFunc()
@@ -23,14 +27,6 @@ func retryTimeDuration(ctx context.Context, duration time.Duration, retryFunc fu
 			return resource.RetryableError(err)
 		}
 
-		if IsTemporarilyUnavailable(err) {
-			return resource.RetryableError(err)
-		}
-
-		if IsRateLimitExceeded(err) {
-			return resource.RetryableError(err)
-		}
-
 		return resource.NonRetryableError(err)
 	})
 }
@@ -78,3 +74,73 @@ func IsRateLimitExceeded(err error) bool {
 	return false
 
 }
+
+// retryPolicy configures how requests to the Google Workspace APIs are retried
+// by the provider's HTTP transport.
+type retryPolicy struct {
+	MaxElapsedTime time.Duration
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Jitter randomizes each backoff by up to this fraction of its value.
+	Jitter float64
+
+	// RetryableStatusCodes and RetryableErrorReasons are retried in addition
+	// to the errors detected by IsTemporarilyUnavailable and IsRateLimitExceeded.
+	RetryableStatusCodes  []int
+	RetryableErrorReasons []string
+}
+
+func defaultRetryPolicy() *retryPolicy {
+	return &retryPolicy{
+		MaxElapsedTime: time.Minute,
+		InitialBackoff: time.Second,
+		MaxBackoff:     30 * time.Second,
+		Jitter:         0.5,
+	}
+}
+
+func (p *retryPolicy) isRetryable(err error) bool {
+	if IsTemporarilyUnavailable(err) || IsRateLimitExceeded(err) {
+		return true
+	}
+
+	gerr, ok := err.(*googleapi.Error)
+	if !ok {
+		return false
+	}
+
+	for _, code := range p.RetryableStatusCodes {
+		if gerr.Code == code {
+			log.Printf("[DEBUG] Dismissed an error as retryable based on configured error code: %s", err)
+			return true
+		}
+	}
+
+	for _, item := range gerr.Errors {
+		if stringInSlice(p.RetryableErrorReasons, item.Reason) {
+			log.Printf("[DEBUG] Dismissed an error as retryable based on configured error reason: %s", err)
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns how long to wait before the given retry attempt (starting at 0),
+// doubling from the initial backoff up to the max backoff, with jitter applied.
+func (p *retryPolicy) backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 0; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+
+	if p.Jitter > 0 {
+		delta := p.Jitter * float64(backoff)
+		backoff = time.Duration(float64(backoff) - delta + rand.Float64()*2*delta)
+	}
+
+	return backoff
+}
@@ -1,8 +1,10 @@
 package googleworkspace
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"google.golang.org/api/googleapi"
 )
@@ -47,3 +49,87 @@ func TestIsTemporarilyUnavailableErrorCode_other(t *testing.T) {
 		t.Errorf("error incorrectly detected as temporarily unavailabl")
 	}
 }
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &retryPolicy{
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Second,
+	}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for attempt, want := range expected {
+		if got := policy.backoff(attempt); got != want {
+			t.Errorf("expected backoff for attempt %d to be %s, got %s", attempt, want, got)
+		}
+	}
+}
+
+func TestRetryPolicyBackoff_jitter(t *testing.T) {
+	policy := &retryPolicy{
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Second,
+		Jitter:         0.5,
+	}
+
+	for i := 0; i < 100; i++ {
+		got := policy.backoff(0)
+		if got < 500*time.Millisecond || got > 1500*time.Millisecond {
+			t.Fatalf("expected backoff between 500ms and 1.5s, got %s", got)
+		}
+	}
+}
+
+func TestRetryPolicyIsRetryable_configured(t *testing.T) {
+	policy := &retryPolicy{
+		RetryableStatusCodes:  []int{500},
+		RetryableErrorReasons: []string{"userRateLimitExceeded"},
+	}
+
+	if !policy.isRetryable(&googleapi.Error{Code: 500}) {
+		t.Errorf("500 error not detected as retryable")
+	}
+
+	if !policy.isRetryable(&googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "userRateLimitExceeded"}}}) {
+		t.Errorf("userRateLimitExceeded error not detected as retryable")
+	}
+
+	if policy.isRetryable(&googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "forbidden"}}}) {
+		t.Errorf("forbidden error incorrectly detected as retryable")
+	}
+
+	if !policy.isRetryable(&googleapi.Error{Code: 503}) {
+		t.Errorf("503 error not detected as retryable")
+	}
+}
+
+func TestRetryTimeDuration_notConsistent(t *testing.T) {
+	attempts := 0
+	err := retryTimeDuration(context.Background(), time.Minute, func() error {
+		attempts++
+		if attempts == 1 {
+			return fmt.Errorf("timed out while waiting for the org unit to be consistent")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+// unavailable requests are retried by the transport instead
+func TestRetryTimeDuration_temporarilyUnavailable(t *testing.T) {
+	attempts := 0
+	err := retryTimeDuration(context.Background(), time.Minute, func() error {
+		attempts++
+		return &googleapi.Error{Code: 503}
+	})
+	if err == nil {
+		t.Fatalf("expected error, but got nil")
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
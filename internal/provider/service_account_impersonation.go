@@ -0,0 +1,137 @@
+package googleworkspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	googleoauth "golang.org/x/oauth2/google"
+	"google.golang.org/api/iamcredentials/v1"
+	"google.golang.org/api/option"
+)
+
+const (
+	cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+	jwtBearerGrantType = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+
+	// signed JWTs are only accepted by the token endpoint for up to an hour
+	signedJwtLifetime = time.Hour
+)
+
+// signJwtTokenSource is an oauth2.TokenSource that mints access tokens for a
+// service account without a key. The JWT assertion, including the subject used
+// for domain-wide delegation, is signed by the IAM Credentials API using the
+// caller's ambient credentials, and is then exchanged at the token endpoint.
+type signJwtTokenSource struct {
+	ctx context.Context
+
+	iamCredentialsService *iamcredentials.Service
+	httpClient            *http.Client
+
+	serviceAccount string
+	subject        string
+	scopes         []string
+	tokenURL       string
+}
+
+// newSignJwtTokenSource returns a token source that signs with the IAM Credentials API
+// using ambientClient, and exchanges the JWT at the default Google token endpoint.
+// Additional options are passed on to the IAM Credentials service.
+func newSignJwtTokenSource(ctx context.Context, ambientClient *http.Client, serviceAccount, subject string, scopes []string, opts ...option.ClientOption) (*signJwtTokenSource, error) {
+	opts = append([]option.ClientOption{option.WithHTTPClient(ambientClient)}, opts...)
+
+	iamCredentialsService, err := iamcredentials.NewService(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &signJwtTokenSource{
+		ctx:                   ctx,
+		iamCredentialsService: iamCredentialsService,
+		httpClient:            oauth2.NewClient(ctx, nil),
+		serviceAccount:        serviceAccount,
+		subject:               subject,
+		scopes:                scopes,
+		tokenURL:              googleoauth.JWTTokenURL,
+	}, nil
+}
+
+func (ts *signJwtTokenSource) Token() (*oauth2.Token, error) {
+	now := time.Now()
+
+	claims := map[string]interface{}{
+		"iss":   ts.serviceAccount,
+		"scope": strings.Join(ts.scopes, " "),
+		"aud":   ts.tokenURL,
+		"iat":   now.Unix(),
+		"exp":   now.Add(signedJwtLifetime).Unix(),
+	}
+	if ts.subject != "" {
+		claims["sub"] = ts.subject
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] Signing JWT for service account %q with subject %q", ts.serviceAccount, ts.subject)
+
+	name := fmt.Sprintf("projects/-/serviceAccounts/%s", ts.serviceAccount)
+	signResp, err := ts.iamCredentialsService.Projects.ServiceAccounts.SignJwt(name, &iamcredentials.SignJwtRequest{
+		Payload: string(payload),
+	}).Context(ts.ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error signing JWT for service account %q: %s", ts.serviceAccount, err)
+	}
+
+	resp, err := ts.httpClient.PostForm(ts.tokenURL, url.Values{
+		"grant_type": {jwtBearerGrantType},
+		"assertion":  {signResp.SignedJwt},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error exchanging signed JWT for service account %q: %s", ts.serviceAccount, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("error exchanging signed JWT for service account %q: %s: %s", ts.serviceAccount,
+			resp.Status, body)
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("error parsing token response for service account %q: %s", ts.serviceAccount, err)
+	}
+
+	if tokenResp.AccessToken == "" {
+		return nil, fmt.Errorf("token response for service account %q did not include an access token", ts.serviceAccount)
+	}
+
+	token := &oauth2.Token{
+		AccessToken: tokenResp.AccessToken,
+		TokenType:   tokenResp.TokenType,
+	}
+	if tokenResp.ExpiresIn > 0 {
+		token.Expiry = now.Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
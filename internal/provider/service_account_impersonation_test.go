@@ -0,0 +1,225 @@
+package googleworkspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/option"
+)
+
+func testSignJwtServer(t *testing.T, claims map[string]interface{}) *httptest.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v1/projects/-/serviceAccounts/sa@my-project.iam.gserviceaccount.com:signJwt", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Payload string `json:"payload"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("error decoding signJwt request: %s", err)
+		}
+		if err := json.Unmarshal([]byte(req.Payload), &claims); err != nil {
+			t.Errorf("error decoding signJwt payload: %s", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"keyId": "foo", "signedJwt": "signed.jwt.value"}`))
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("error parsing token request: %s", err)
+		}
+		if r.PostForm.Get("grant_type") != jwtBearerGrantType {
+			t.Errorf("expected grant_type %q, got %q", jwtBearerGrantType, r.PostForm.Get("grant_type"))
+		}
+		if r.PostForm.Get("assertion") != "signed.jwt.value" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "invalid_grant"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "delegated-token", "token_type": "Bearer", "expires_in": 3600}`))
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestSignJwtTokenSource(t *testing.T) {
+	claims := map[string]interface{}{}
+	server := testSignJwtServer(t, claims)
+	defer server.Close()
+
+	ts, err := newSignJwtTokenSource(context.Background(), server.Client(), "sa@my-project.iam.gserviceaccount.com",
+		"admin@example.com", []string{"scope1", "scope2"}, option.WithEndpoint(server.URL+"/"))
+	if err != nil {
+		t.Fatalf("error creating token source: %s", err)
+	}
+	ts.tokenURL = server.URL + "/token"
+
+	token, err := ts.Token()
+	if err != nil {
+		t.Fatalf("error getting token: %s", err)
+	}
+
+	if token.AccessToken != "delegated-token" {
+		t.Errorf("expected access token %q, got %q", "delegated-token", token.AccessToken)
+	}
+	if token.Expiry.IsZero() {
+		t.Errorf("expected token expiry to be set")
+	}
+
+	expected := map[string]string{
+		"iss":   "sa@my-project.iam.gserviceaccount.com",
+		"sub":   "admin@example.com",
+		"scope": "scope1 scope2",
+		"aud":   server.URL + "/token",
+	}
+	for k, v := range expected {
+		if claims[k] != v {
+			t.Errorf("expected claim %q to be %q, got %v", k, v, claims[k])
+		}
+	}
+}
+
+func TestSignJwtTokenSource_noSubject(t *testing.T) {
+	claims := map[string]interface{}{}
+	server := testSignJwtServer(t, claims)
+	defer server.Close()
+
+	ts, err := newSignJwtTokenSource(context.Background(), server.Client(), "sa@my-project.iam.gserviceaccount.com",
+		"", []string{"scope1"}, option.WithEndpoint(server.URL+"/"))
+	if err != nil {
+		t.Fatalf("error creating token source: %s", err)
+	}
+	ts.tokenURL = server.URL + "/token"
+
+	if _, err := ts.Token(); err != nil {
+		t.Fatalf("error getting token: %s", err)
+	}
+
+	if _, ok := claims["sub"]; ok {
+		t.Errorf("expected no sub claim, got %v", claims["sub"])
+	}
+}
+
+func TestSignJwtTokenSource_signError(t *testing.T) {
+	server := testSignJwtServer(t, map[string]interface{}{})
+	defer server.Close()
+
+	ts, err := newSignJwtTokenSource(context.Background(), server.Client(), "unknown@my-project.iam.gserviceaccount.com",
+		"admin@example.com", []string{"scope1"}, option.WithEndpoint(server.URL+"/"))
+	if err != nil {
+		t.Fatalf("error creating token source: %s", err)
+	}
+	ts.tokenURL = server.URL + "/token"
+
+	if _, err := ts.Token(); err == nil {
+		t.Fatalf("expected error, but got nil")
+	}
+}
+
+func TestConfigLoadAndValidate_impersonateServiceAccount(t *testing.T) {
+	config := &apiClient{
+		Credentials:               testFakeExternalAccountPath,
+		ImpersonateServiceAccount: "sa@my-project.iam.gserviceaccount.com",
+		ImpersonatedUserEmail:     "my-fake-email@example.com",
+	}
+
+	diags := config.loadAndValidate(context.Background())
+	err := checkDiags(diags)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if config.client == nil {
+		t.Fatalf("expected client to be set when impersonating a service account")
+	}
+}
+
+// testRedirectTransport sends every request to the test server, whatever its
+// host, so the default Google endpoints can be faked.
+type testRedirectTransport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+func (t *testRedirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	redirected := req.Clone(req.Context())
+	redirected.URL.Scheme = t.target.Scheme
+	redirected.URL.Host = t.target.Host
+	redirected.Host = t.target.Host
+
+	return t.base.RoundTrip(redirected)
+}
+
+// The JWT is signed on the first request, after the context the provider is
+// configured with is canceled.
+func TestConfigLoadAndValidate_impersonateServiceAccountCanceledContext(t *testing.T) {
+	signJwtServer := testSignJwtServer(t, map[string]interface{}{})
+	defer signJwtServer.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/token":
+			// the ambient credentials are exchanged at the STS endpoint
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"access_token": "ambient-token", "issued_token_type": "urn:ietf:params:oauth:token-type:access_token", "token_type": "Bearer", "expires_in": 3600}`))
+		case "/check":
+			if got := r.Header.Get("Authorization"); got != "Bearer delegated-token" {
+				t.Errorf("expected the delegated token, got %q", got)
+			}
+		default:
+			signJwtServer.Config.Handler.ServeHTTP(w, r)
+		}
+	}))
+	defer server.Close()
+
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	subjectTokenPath := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(subjectTokenPath, []byte("subject-token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &apiClient{
+		Credentials: fmt.Sprintf(`{
+  "type": "external_account",
+  "audience": "//iam.googleapis.com/projects/123456789/locations/global/workloadIdentityPools/foo/providers/bar",
+  "subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+  "token_url": "https://sts.googleapis.com/v1/token",
+  "credential_source": {
+    "file": "%s"
+  }
+}`, subjectTokenPath),
+		ImpersonateServiceAccount: "sa@my-project.iam.gserviceaccount.com",
+		ImpersonatedUserEmail:     "admin@example.com",
+	}
+
+	httpClient := &http.Client{Transport: &testRedirectTransport{target: target, base: server.Client().Transport}}
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), oauth2.HTTPClient, httpClient))
+	diags := config.loadAndValidate(ctx)
+	cancel()
+	if err := checkDiags(diags); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	resp, err := config.client.Get("https://admin.googleapis.com/check")
+	if err != nil {
+		t.Fatalf("error sending request after the context was canceled: %s", err)
+	}
+	resp.Body.Close()
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/mitchellh/go-homedir"
 
+	directory "google.golang.org/api/admin/directory/v1"
 	"google.golang.org/api/googleapi"
 )
 
@@ -197,3 +198,27 @@ func sortListOfInterfaces(v []interface{}) []string {
 	sort.Strings(newVal)
 	return newVal
 }
+
+// findAlias returns the alias from a list of the aliases of a user or group,
+// or nil if it isn't listed. Aliases are email addresses, so they're compared
+// case-insensitively.
+func findAlias(aliases *directory.Aliases, alias string) *directory.Alias {
+	// the aliases are listed as JSON objects
+	for _, a := range aliases.Aliases {
+		obj, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		found := &directory.Alias{}
+		found.Alias, _ = obj["alias"].(string)
+		found.Id, _ = obj["id"].(string)
+		found.PrimaryEmail, _ = obj["primaryEmail"].(string)
+
+		if strings.EqualFold(found.Alias, alias) {
+			return found
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,558 @@
+package googleworkspace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/oauth2"
+	directory "google.golang.org/api/admin/directory/v1"
+)
+
+const (
+	// vcrModeEnvVar records the requests made by each acceptance test to a
+	// cassette, or replays them from it.
+	vcrModeEnvVar = "GOOGLEWORKSPACE_VCR_MODE"
+	vcrPathEnvVar = "GOOGLEWORKSPACE_VCR_PATH"
+
+	vcrModeRecord = "record"
+	vcrModeReplay = "replay"
+
+	vcrDefaultPath = "test-data"
+
+	// cassettes have these in place of the values of the tenant they're
+	// recorded against
+	vcrCustomerId = "C00vcr000"
+	vcrDomain     = "example.com"
+	vcrAdminEmail = "admin@" + vcrDomain
+	vcrRedacted   = "REDACTED"
+)
+
+// vcrCassette is every request made by a test, along with the seed of the
+// random names it uses.
+type vcrCassette struct {
+	Seed         int64             `json:"seed"`
+	Interactions []*vcrInteraction `json:"interactions"`
+}
+
+type vcrInteraction struct {
+	Request  vcrRequest  `json:"request"`
+	Response vcrResponse `json:"response"`
+
+	replayed bool
+}
+
+type vcrRequest struct {
+	Method      string `json:"method"`
+	URL         string `json:"url"`
+	IfNoneMatch string `json:"if_none_match,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+type vcrResponse struct {
+	StatusCode int               `json:"status_code"`
+	Header     map[string]string `json:"header,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+// Only these response headers are recorded.
+var vcrResponseHeaders = []string{"Content-Type", "Etag"}
+
+// Fields with these names are redacted from request and response bodies.
+var vcrRedactedFields = map[string]bool{
+	"password":      true,
+	"access_token":  true,
+	"id_token":      true,
+	"refresh_token": true,
+	"signedJwt":     true,
+}
+
+// vcrRecorder records the requests of a test to its cassette, or replays them.
+type vcrRecorder struct {
+	mode      string
+	path      string
+	transport http.RoundTripper
+	sanitizer *strings.Replacer
+
+	mutex    sync.Mutex
+	cassette *vcrCassette
+	rand     *rand.Rand
+}
+
+var (
+	vcrRecordersMutex sync.Mutex
+	vcrRecorders      = map[string]*vcrRecorder{}
+)
+
+func vcrMode() string {
+	return os.Getenv(vcrModeEnvVar)
+}
+
+func vcrCassettePath(name string) string {
+	dir := os.Getenv(vcrPathEnvVar)
+	if dir == "" {
+		dir = vcrDefaultPath
+	}
+
+	return filepath.Join(dir, strings.ReplaceAll(name, "/", "_")+".json")
+}
+
+// newVcrRecorder returns a recorder of the cassette at path. When recording,
+// each of the keys of sanitize is replaced by its value in what's recorded.
+func newVcrRecorder(mode, path string, sanitize map[string]string) (*vcrRecorder, error) {
+	v := &vcrRecorder{
+		mode:      mode,
+		path:      path,
+		transport: cleanhttp.DefaultTransport(),
+		cassette:  &vcrCassette{Seed: time.Now().UnixNano()},
+	}
+
+	var pairs []string
+	for old, new := range sanitize {
+		if old == "" || old == new {
+			continue
+		}
+		// values are also replaced where they're escaped in a URL
+		pairs = append(pairs, old, new, url.QueryEscape(old), url.QueryEscape(new))
+	}
+	v.sanitizer = strings.NewReplacer(pairs...)
+
+	switch mode {
+	case vcrModeRecord:
+	case vcrModeReplay:
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(contents, v.cassette); err != nil {
+			return nil, fmt.Errorf("error reading cassette %s: %s", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("%s must be %q or %q, got %q", vcrModeEnvVar, vcrModeRecord, vcrModeReplay, mode)
+	}
+
+	v.rand = rand.New(rand.NewSource(v.cassette.Seed))
+
+	return v, nil
+}
+
+// vcrTest returns the recorder of the test, or nil if requests aren't being
+// recorded or replayed. The test is skipped if there's nothing to replay.
+func vcrTest(t *testing.T) *vcrRecorder {
+	mode := vcrMode()
+	if mode == "" {
+		return nil
+	}
+
+	vcrRecordersMutex.Lock()
+	defer vcrRecordersMutex.Unlock()
+
+	if v, ok := vcrRecorders[t.Name()]; ok {
+		return v
+	}
+
+	path := vcrCassettePath(t.Name())
+	if _, err := os.Stat(path); mode == vcrModeReplay && os.IsNotExist(err) {
+		t.Skipf("no cassette to replay at %s", path)
+	}
+
+	// both users are replayed as the admin, so it doesn't matter whether they
+	// were the same user when recording
+	v, err := newVcrRecorder(mode, path, map[string]string{
+		os.Getenv("GOOGLEWORKSPACE_IMPERSONATED_USER_EMAIL"): vcrAdminEmail,
+		os.Getenv("GOOGLEWORKSPACE_TEST_GMAIL_USER"):         vcrAdminEmail,
+		os.Getenv("GOOGLEWORKSPACE_CUSTOMER_ID"):             vcrCustomerId,
+		os.Getenv("GOOGLEWORKSPACE_DOMAIN"):                  vcrDomain,
+	})
+	if err != nil {
+		t.Fatalf("error loading cassette: %s", err)
+	}
+	vcrRecorders[t.Name()] = v
+
+	t.Cleanup(func() {
+		vcrRecordersMutex.Lock()
+		delete(vcrRecorders, t.Name())
+		vcrRecordersMutex.Unlock()
+
+		// failed runs aren't recorded, so they can be recorded again
+		if mode == vcrModeRecord && !t.Failed() {
+			if err := v.save(); err != nil {
+				t.Errorf("error saving cassette: %s", err)
+			}
+		}
+	})
+
+	return v
+}
+
+func (v *vcrRecorder) save() error {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	contents, err := json.MarshalIndent(v.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(v.path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(v.path, contents, 0644)
+}
+
+func (v *vcrRecorder) client() *http.Client {
+	return &http.Client{Transport: v}
+}
+
+func (v *vcrRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	if v.mode == vcrModeReplay {
+		return v.replay(req, v.request(req, body))
+	}
+
+	resp, err := v.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	interaction := &vcrInteraction{
+		Request: v.request(req, body),
+		Response: vcrResponse{
+			StatusCode: resp.StatusCode,
+			Header:     map[string]string{},
+			Body:       v.sanitizer.Replace(redactVcrBody(resp.Header.Get("Content-Type"), respBody)),
+		},
+	}
+	for _, k := range vcrResponseHeaders {
+		if value := resp.Header.Get(k); value != "" {
+			interaction.Response.Header[k] = v.sanitizer.Replace(value)
+		}
+	}
+
+	v.mutex.Lock()
+	v.cassette.Interactions = append(v.cassette.Interactions, interaction)
+	v.mutex.Unlock()
+
+	return resp, nil
+}
+
+// request returns the request as it's recorded and matched. Token exchanges
+// contain signed JWTs, which differ every time, so they're matched by URL.
+func (v *vcrRecorder) request(req *http.Request, body []byte) vcrRequest {
+	r := vcrRequest{
+		Method:      req.Method,
+		URL:         v.sanitizer.Replace(req.URL.String()),
+		IfNoneMatch: req.Header.Get("If-None-Match"),
+	}
+	if !isVcrTokenRequest(req) {
+		r.Body = v.sanitizer.Replace(redactVcrBody(req.Header.Get("Content-Type"), body))
+	}
+
+	return r
+}
+
+// replay responds with the first recorded response to the same request that
+// hasn't been replayed yet.
+func (v *vcrRecorder) replay(req *http.Request, r vcrRequest) (*http.Response, error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	for _, interaction := range v.cassette.Interactions {
+		if interaction.replayed || interaction.Request != r {
+			continue
+		}
+		interaction.replayed = true
+
+		header := http.Header{}
+		for k, value := range interaction.Response.Header {
+			header.Set(k, value)
+		}
+
+		return &http.Response{
+			Status:        http.StatusText(interaction.Response.StatusCode),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded response to %s %s in %s", r.Method, r.URL, v.path)
+}
+
+func (v *vcrRecorder) randString(n int) string {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	result := make([]byte, n)
+	for i := range result {
+		result[i] = acctest.CharSetAlphaNum[v.rand.Intn(len(acctest.CharSetAlphaNum))]
+	}
+
+	return string(result)
+}
+
+func isVcrTokenRequest(req *http.Request) bool {
+	return strings.HasSuffix(req.URL.Path, "/token") || strings.HasSuffix(req.URL.Path, ":signJwt")
+}
+
+// redactVcrBody redacts secrets from a JSON body, which is also made canonical
+// so it matches regardless of field order. The random boundary of a multipart
+// body is replaced.
+func redactVcrBody(contentType string, body []byte) string {
+	var fields interface{}
+	if err := json.Unmarshal(body, &fields); err == nil {
+		return string(mustMarshalFake(redactVcrFields(fields)))
+	}
+
+	if _, params, err := mime.ParseMediaType(contentType); err == nil && params["boundary"] != "" {
+		return strings.ReplaceAll(string(body), params["boundary"], "vcr-boundary")
+	}
+
+	return string(body)
+}
+
+func redactVcrFields(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			if vcrRedactedFields[k] {
+				v[k] = vcrRedacted
+			} else {
+				v[k] = redactVcrFields(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactVcrFields(value)
+		}
+	}
+
+	return v
+}
+
+// randString returns a random string for the test, which is the same every
+// time its cassette is replayed.
+func randString(t *testing.T, n int) string {
+	if v := vcrTest(t); v != nil {
+		return v.randString(n)
+	}
+
+	return acctest.RandString(n)
+}
+
+// vcrContext returns the context the test's requests are made with.
+func vcrContext(t *testing.T) context.Context {
+	ctx := context.Background()
+	if v := vcrTest(t); v != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, v.client())
+	}
+
+	return ctx
+}
+
+// testAccProviderFactories returns the provider factories of the test, whose
+// requests are recorded or replayed if configured.
+func testAccProviderFactories(t *testing.T) map[string]func() (*schema.Provider, error) {
+	if vcrTest(t) == nil {
+		return providerFactories
+	}
+
+	return map[string]func() (*schema.Provider, error){
+		"googleworkspace": func() (*schema.Provider, error) {
+			p := New("dev")()
+
+			configure := p.ConfigureContextFunc
+			p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+				return configure(context.WithValue(ctx, oauth2.HTTPClient, vcrTest(t).client()), d)
+			}
+
+			return p, nil
+		},
+	}
+}
+
+// testAccClient returns a client for the checks of the test, whose requests
+// are recorded or replayed along with those of the provider.
+func testAccClient(t *testing.T) (*apiClient, error) {
+	return googleworkspaceTestClientWithContext(vcrContext(t))
+}
+
+// startVcr configures the tests to replay their cassettes, if they're
+// configured to. Replays use placeholder credentials, as no tokens are issued.
+func startVcr() {
+	if vcrMode() != vcrModeReplay {
+		return
+	}
+
+	creds, err := testServiceAccountCredentials("https://oauth2.googleapis.com/token")
+	if err != nil {
+		log.Fatalf("[ERROR] Failed to generate credentials to replay the tests with: %s", err)
+	}
+
+	for _, k := range credsEnvVars {
+		os.Unsetenv(k)
+	}
+	for _, k := range []string{
+		"GOOGLEWORKSPACE_CHROME_POLICY_CUSTOM_ENDPOINT",
+		"GOOGLEWORKSPACE_DIRECTORY_CUSTOM_ENDPOINT",
+		"GOOGLEWORKSPACE_GMAIL_CUSTOM_ENDPOINT",
+		"GOOGLEWORKSPACE_GROUPS_SETTINGS_CUSTOM_ENDPOINT",
+	} {
+		os.Unsetenv(k)
+	}
+
+	env := map[string]string{
+		"GOOGLEWORKSPACE_CREDENTIALS":             creds,
+		"GOOGLEWORKSPACE_CUSTOMER_ID":             vcrCustomerId,
+		"GOOGLEWORKSPACE_DOMAIN":                  vcrDomain,
+		"GOOGLEWORKSPACE_IMPERSONATED_USER_EMAIL": vcrAdminEmail,
+		"GOOGLEWORKSPACE_TEST_GMAIL_USER":         vcrAdminEmail,
+	}
+	for k, v := range env {
+		os.Setenv(k, v)
+	}
+
+	log.Printf("[INFO] Replaying the tests from their cassettes")
+}
+
+func TestVcr_recordAndReplay(t *testing.T) {
+	f := newFakeWorkspace()
+	defer f.Close()
+
+	creds, err := f.fakeCredentials()
+	if err != nil {
+		t.Fatalf("error generating fake credentials: %s", err)
+	}
+	path := filepath.Join(t.TempDir(), "TestVcr.json")
+
+	// run makes the same requests when recording and replaying, returning
+	// what they read
+	run := func(v *vcrRecorder, customer string) (string, string, error) {
+		client := &apiClient{
+			Credentials:                creds,
+			Customer:                   customer,
+			ImpersonatedUserEmail:      fakeAdminEmail,
+			ChromePolicyCustomEndpoint: f.server.URL + "/",
+			DirectoryCustomEndpoint:    f.server.URL + "/",
+		}
+		if diags := client.loadAndValidate(context.WithValue(context.Background(), oauth2.HTTPClient, v.client())); diags.HasError() {
+			return "", "", fmt.Errorf("error loading client: %s", diags[0].Summary)
+		}
+
+		directoryService, diags := client.NewDirectoryService()
+		if diags.HasError() {
+			return "", "", fmt.Errorf("error creating directory service: %s", diags[0].Summary)
+		}
+		chromePolicyService, diags := client.NewChromePolicyService()
+		if diags.HasError() {
+			return "", "", fmt.Errorf("error creating chrome policy service: %s", diags[0].Summary)
+		}
+
+		user, err := directoryService.Users.Insert(&directory.User{
+			PrimaryEmail: "tf-test-" + v.randString(10) + "@" + fakeDomain,
+			Password:     "password123",
+			Name:         &directory.UserName{GivenName: "Test", FamilyName: "User"},
+		}).Do()
+		if err != nil {
+			return "", "", err
+		}
+
+		user, err = directoryService.Users.Get(user.Id).Do()
+		if err != nil {
+			return "", "", err
+		}
+
+		policySchema, err := chromePolicyService.Customers.PolicySchemas.Get(fmt.Sprintf("customers/%s/policySchemas/chrome.users.MaxConnectionsPerProxy", customer)).Do()
+		if err != nil {
+			return "", "", err
+		}
+
+		return user.PrimaryEmail, policySchema.Name, nil
+	}
+
+	recorder, err := newVcrRecorder(vcrModeRecord, path, map[string]string{fakeCustomerId: vcrCustomerId})
+	if err != nil {
+		t.Fatalf("error creating recorder: %s", err)
+	}
+	recordedEmail, recordedSchema, err := run(recorder, fakeCustomerId)
+	if err != nil {
+		t.Fatalf("error recording: %s", err)
+	}
+	if err := recorder.save(); err != nil {
+		t.Fatalf("error saving cassette: %s", err)
+	}
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("error reading cassette: %s", err)
+	}
+	for _, secret := range []string{"password123", fakeCustomerId, fakeAccessToken} {
+		if strings.Contains(string(contents), secret) {
+			t.Errorf("expected %q to be sanitized from the cassette", secret)
+		}
+	}
+
+	// the fake is gone, so everything is replayed
+	f.Close()
+
+	replayer, err := newVcrRecorder(vcrModeReplay, path, nil)
+	if err != nil {
+		t.Fatalf("error loading cassette: %s", err)
+	}
+	replayedEmail, replayedSchema, err := run(replayer, vcrCustomerId)
+	if err != nil {
+		t.Fatalf("error replaying: %s", err)
+	}
+
+	if replayedEmail != recordedEmail {
+		t.Errorf("expected the replayed random name to be %q, got %q", recordedEmail, replayedEmail)
+	}
+	if expected := strings.ReplaceAll(recordedSchema, fakeCustomerId, vcrCustomerId); replayedSchema != expected {
+		t.Errorf("expected the replayed schema to be %q, got %q", expected, replayedSchema)
+	}
+
+	// each response is replayed once
+	if _, _, err := run(replayer, vcrCustomerId); err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Errorf("expected requests that weren't recorded to fail, got %v", err)
+	}
+}